@@ -48,6 +48,10 @@ const (
 	ArgIPAddress = "ip-address"
 	// ArgDropletName is a droplet name argument.
 	ArgDropletName = "droplet-name"
+	// ArgName is a resource name filter argument.
+	ArgName = "name"
+	// ArgNameRegex is a resource name regular expression filter argument.
+	ArgNameRegex = "name-regex"
 	// ArgResizeDisk is a resize disk argument.
 	ArgResizeDisk = "resize-disk"
 	// ArgSnapshotName is a snapshot name arugment.
@@ -72,6 +76,12 @@ const (
 	ArgRecordType = "record-type"
 	// ArgRecordWeight is a record weight argument.
 	ArgRecordWeight = "record-weight"
+	// ArgRecordVerify is a wait for record propagation argument.
+	ArgRecordVerify = "verify"
+	// ArgRecordVerifyTimeout is a record propagation timeout argument.
+	ArgRecordVerifyTimeout = "verify-timeout"
+	// ArgRecordResolvers is an additional resolvers to verify against argument.
+	ArgRecordResolvers = "resolvers"
 	// ArgRegionSlug is a region slug argument.
 	ArgRegionSlug = "region"
 	// ArgSizeSlug is a size slug argument.
@@ -84,6 +94,20 @@ const (
 	ArgsSSHPort = "ssh-port"
 	// ArgsSSHAgentForwarding is a ssh argument.
 	ArgsSSHAgentForwarding = "ssh-agent-forwarding"
+	// ArgsSSHPrivateIP is a ssh argument.
+	ArgsSSHPrivateIP = "ssh-private-ip"
+	// ArgsSSHJump is a ssh argument.
+	ArgsSSHJump = "ssh-jump"
+	// ArgsSSHLocalForward is a ssh argument.
+	ArgsSSHLocalForward = "local-forward"
+	// ArgsSSHRemoteForward is a ssh argument.
+	ArgsSSHRemoteForward = "remote-forward"
+	// ArgsSSHStrictHostChecking is a ssh argument.
+	ArgsSSHStrictHostChecking = "ssh-strict-host-checking"
+	// ArgsSSHKnownHostsFile is a ssh argument.
+	ArgsSSHKnownHostsFile = "ssh-known-hosts-file"
+	// ArgsSSHHostKeyAlias is a ssh option naming the pinned host key.
+	ArgsSSHHostKeyAlias = "ssh-host-key-alias"
 	// ArgUserData is a user data argument.
 	ArgUserData = "user-data"
 	// ArgUserDataFile is a user data file location argument.
@@ -104,13 +128,47 @@ const (
 	ArgFormat = "format"
 	// ArgNoHeader hides the output header.
 	ArgNoHeader = "no-header"
+	// ArgSnapshotResource is a snapshot resource type argument.
+	ArgSnapshotResource = "resource"
+	// ArgOlderThan is a minimum age argument.
+	ArgOlderThan = "older-than"
+	// ArgKeepLast is a number of newest items to keep argument.
+	ArgKeepLast = "keep-last"
+	// ArgNamePrefix is a name prefix argument.
+	ArgNamePrefix = "name-prefix"
+	// ArgCount is a number of resources argument.
+	ArgCount = "count"
+	// ArgMonitoring is an enable monitoring argument.
+	ArgMonitoring = "monitoring"
+	// ArgKeep is a number of items to keep argument.
+	ArgKeep = "keep"
+	// ArgNameTemplate is a name template argument.
+	ArgNameTemplate = "name-template"
+	// ArgForce is a perform destructive changes argument.
+	ArgForce = "force"
+	// ArgDropletStatus is a droplet status argument.
+	ArgDropletStatus = "status"
+	// ArgTimeout is a timeout in seconds argument.
+	ArgTimeout = "timeout"
 	// ArgPollTime is how long before the next poll argument.
 	ArgPollTime = "poll-timeout"
 	// ArgTagName is a tag name
 	ArgTagName = "tag-name"
 
+	// ArgOffline is a skip API lookups argument.
+	ArgOffline = "offline"
 	// ArgOutput is an output type argument.
 	ArgOutput = "output"
+	// ArgQuiet is an only print identifiers argument.
+	ArgQuiet = "quiet"
+	// ArgNoColor is a disable colored output argument.
+	ArgNoColor = "no-color"
+	// ArgProgress is a progress output mode argument.
+	ArgProgress = "progress"
+	// ArgAuditLog is an audit log file path argument.
+	ArgAuditLog = "audit-log"
+	// ArgContext is a config context argument.
+	ArgContext = "context"
 
 	// ArgVolumeSize is the size of a volume.
 	ArgVolumeSize = "size"
@@ -120,4 +178,54 @@ const (
 	ArgVolumeRegion = "region"
 	// ArgVolumeList is the IDs of many volumes.
 	ArgVolumeList = "volumes"
+	// ArgFeatures is a region features argument.
+	ArgFeatures = "features"
+	// ArgAvailable is an availability argument.
+	ArgAvailable = "available"
+	// ArgGPUs is a GPU sizes only argument.
+	ArgGPUs = "gpus"
+	// ArgMinVCPUs is a minimum number of vcpus argument.
+	ArgMinVCPUs = "min-vcpus"
+	// ArgMinMemory is a minimum memory argument.
+	ArgMinMemory = "min-memory"
+	// ArgMaxPriceMonthly is a maximum monthly price argument.
+	ArgMaxPriceMonthly = "max-price-monthly"
+	// ArgNoBrowser is a do not open a web browser argument.
+	ArgNoBrowser = "no-browser"
+	// ArgCreateWWW is a create www record argument.
+	ArgCreateWWW = "create-www"
+	// ArgProvider is a foreign DNS provider argument.
+	ArgProvider = "provider"
+	// ArgFromFile is a read input from file argument.
+	ArgFromFile = "from-file"
+	// ArgContinueOnError is a keep going after a failure argument.
+	ArgContinueOnError = "continue-on-error"
+	// ArgFile is a file location argument.
+	ArgFile = "file"
+	// ArgPrune is a remove undeclared resources argument.
+	ArgPrune = "prune"
+	// ArgExportResources is a list of resource types to export.
+	ArgExportResources = "resource"
+	// ArgExportImportFile is a file to write terraform import commands to.
+	ArgExportImportFile = "import-file"
+	// ArgToContext is a destination config context argument.
+	ArgToContext = "to-context"
+	// ArgMove is a remove the source after copying argument.
+	ArgMove = "move"
+	// ArgGraceful is a shut down before deleting argument.
+	ArgGraceful = "graceful"
+	// ArgFromIP is a current record address argument.
+	ArgFromIP = "from-ip"
+	// ArgToIP is a new record address argument.
+	ArgToIP = "to-ip"
+	// ArgRecordTTL is a record TTL in seconds argument.
+	ArgRecordTTL = "ttl"
+	// ArgRestoreTTL is a put the original TTL back argument.
+	ArgRestoreTTL = "restore-ttl"
+	// ArgBatchSize is a number of resources handled at once argument.
+	ArgBatchSize = "batch-size"
+	// ArgWaitHealthyPort is a health probe port argument.
+	ArgWaitHealthyPort = "wait-healthy-port"
+	// ArgWaitHealthyPath is a health probe HTTP path argument.
+	ArgWaitHealthyPath = "wait-healthy-path"
 )
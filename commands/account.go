@@ -13,7 +13,11 @@ limitations under the License.
 
 package commands
 
-import "github.com/spf13/cobra"
+import (
+	"sync"
+
+	"github.com/spf13/cobra"
+)
 
 // Account creates the account commands heirarchy.
 func Account() *Command {
@@ -33,6 +37,9 @@ func Account() *Command {
 	CmdBuilder(cmd, RunAccountRateLimit, "ratelimit", "get API rate limits", Writer,
 		aliasOpt("rl"), displayerType(&rateLimit{}), docCategories("account"))
 
+	CmdBuilder(cmd, RunAccountSummary, "summary", "count resources against account limits", Writer,
+		aliasOpt("s"), displayerType(&accountSummary{}), docCategories("account"))
+
 	return cmd
 }
 
@@ -55,3 +62,78 @@ func RunAccountRateLimit(c *CmdConfig) error {
 
 	return c.Display(&rateLimit{RateLimit: rl})
 }
+
+// resourceCount is the number of resources of a type and the account limit
+// for it. A Limit of 0 means the API reports no limit.
+type resourceCount struct {
+	Resource string `json:"resource"`
+	Count    int    `json:"count"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// RunAccountSummary counts the account's resources, listing each type
+// concurrently.
+func RunAccountSummary(c *CmdConfig) error {
+	counters := []struct {
+		resource string
+		count    func() (int, error)
+	}{
+		{"droplets", func() (int, error) {
+			l, err := c.Droplets().List()
+			return len(l), err
+		}},
+		{"floating ips", func() (int, error) {
+			l, err := c.FloatingIPs().List()
+			return len(l), err
+		}},
+		{"volumes", func() (int, error) {
+			l, err := c.Volumes().List()
+			return len(l), err
+		}},
+		{"domains", func() (int, error) {
+			l, err := c.Domains().List()
+			return len(l), err
+		}},
+		{"ssh keys", func() (int, error) {
+			l, err := c.Keys().List()
+			return len(l), err
+		}},
+		{"images", func() (int, error) {
+			l, err := c.Images().ListUser(false)
+			return len(l), err
+		}},
+		{"tags", func() (int, error) {
+			l, err := c.Tags().List()
+			return len(l), err
+		}},
+	}
+
+	var wg sync.WaitGroup
+	counts := make([]resourceCount, len(counters))
+	errs := make([]error, len(counters)+1)
+
+	for i, rc := range counters {
+		wg.Add(1)
+		go func(i int, resource string, count func() (int, error)) {
+			defer wg.Done()
+			n, err := count()
+			counts[i] = resourceCount{Resource: resource, Count: n}
+			errs[i] = err
+		}(i, rc.resource, rc.count)
+	}
+
+	a, err := c.Account().Get()
+	errs[len(counters)] = err
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	counts[0].Limit = a.DropletLimit
+	counts[1].Limit = a.FloatingIPLimit
+
+	return c.Display(&accountSummary{counts: counts})
+}
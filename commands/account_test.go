@@ -14,8 +14,11 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
@@ -33,7 +36,7 @@ var testAccount = &do.Account{
 func TestAccountCommand(t *testing.T) {
 	acctCmd := Account()
 	assert.NotNil(t, acctCmd)
-	assertCommandNames(t, acctCmd, "get", "ratelimit")
+	assertCommandNames(t, acctCmd, "get", "ratelimit", "summary")
 }
 
 func TestAccountGet(t *testing.T) {
@@ -44,3 +47,28 @@ func TestAccountGet(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestAccountSummary(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.account.On("Get").Return(testAccount, nil)
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.floatingIPs.On("List").Return(testFloatingIPList, nil)
+		tm.volumes.On("List").Return([]do.Volume{}, nil)
+		tm.domains.On("List").Return(do.Domains{}, nil)
+		tm.keys.On("List").Return(do.SSHKeys{}, nil)
+		tm.images.On("ListUser", false).Return(do.Images{}, nil)
+		tm.tags.On("List").Return(do.Tags{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunAccountSummary(config)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 7)
+		assert.Equal(t, []string{"droplets", "2", "10"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"floating", "ips", "1"}, strings.Fields(lines[1]))
+	})
+}
@@ -41,11 +41,7 @@ func Actions() *Command {
 	cmdActionList := CmdBuilder(cmd, RunCmdActionList, "list", "list actions", Writer,
 		aliasOpt("ls"), displayerType(&action{}), docCategories("action"))
 	AddStringFlag(cmdActionList, doctl.ArgActionResourceType, "", "Action resource type")
-	AddStringFlag(cmdActionList, doctl.ArgActionRegion, "", "Action region")
-	AddStringFlag(cmdActionList, doctl.ArgActionAfter, "", "Action completed after in RFC3339 format")
-	AddStringFlag(cmdActionList, doctl.ArgActionBefore, "", "Action completed before in RFC3339 format")
-	AddStringFlag(cmdActionList, doctl.ArgActionStatus, "", "Action status")
-	AddStringFlag(cmdActionList, doctl.ArgActionType, "", "Action type")
+	addActionFilterFlags(cmdActionList)
 
 	cmdActionWait := CmdBuilder(cmd, RunCmdActionWait, "wait ACTIONID", "wait for action to complete", Writer,
 		aliasOpt("w"), displayerType(&action{}), docCategories("action"))
@@ -72,6 +68,16 @@ func RunCmdActionList(c *CmdConfig) error {
 	return c.Display(item)
 }
 
+// addActionFilterFlags adds the flags read by filterActionList, apart from
+// the resource type, to a command listing actions.
+func addActionFilterFlags(cmd *Command) {
+	AddStringFlag(cmd, doctl.ArgActionRegion, "", "Action region")
+	AddStringFlag(cmd, doctl.ArgActionAfter, "", "Action completed after in RFC3339 format")
+	AddStringFlag(cmd, doctl.ArgActionBefore, "", "Action completed before in RFC3339 format")
+	AddStringFlag(cmd, doctl.ArgActionStatus, "", "Action status")
+	AddStringFlag(cmd, doctl.ArgActionType, "", "Action type")
+}
+
 type actionsByCompletedAt do.Actions
 
 func (a actionsByCompletedAt) Len() int {
@@ -81,6 +87,10 @@ func (a actionsByCompletedAt) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 func (a actionsByCompletedAt) Less(i, j int) bool {
+	// actions still in progress have no completion time and sort last.
+	if a[i].CompletedAt == nil || a[j].CompletedAt == nil {
+		return a[i].CompletedAt != nil
+	}
 	return a[i].CompletedAt.Before(a[j].CompletedAt.Time)
 }
 
@@ -148,7 +158,8 @@ func filterActionList(c *CmdConfig, in do.Actions) (do.Actions, error) {
 			match = false
 		}
 
-		if a.CompletedAt == nil {
+		// an action that hasn't completed can't fall inside a time window.
+		if a.CompletedAt == nil && (!isZeroTime(before) || !isZeroTime(after)) {
 			match = false
 		}
 
@@ -223,9 +234,15 @@ func actionWait(c *CmdConfig, actionID, pollTime int) (*do.Action, error) {
 	var a *do.Action
 	var err error
 
+	done := inFlight.track("action %d", actionID)
+	defer done()
+
+	p := startProgress("action %d", actionID)
+
 	for {
 		a, err = as.Get(actionID)
 		if err != nil {
+			p.done("error")
 			return nil, err
 		}
 
@@ -233,8 +250,13 @@ func actionWait(c *CmdConfig, actionID, pollTime int) (*do.Action, error) {
 			break
 		}
 
-		time.Sleep(time.Duration(pollTime) * time.Second)
+		p.update(a.Status)
+		if err := sleepContext(c.ctx(), time.Duration(pollTime)*time.Second); err != nil {
+			p.done("cancelled")
+			return nil, err
+		}
 	}
 
+	p.done(a.Status)
 	return a, nil
 }
@@ -0,0 +1,337 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// aliasesKey is the config file key aliases are stored under.
+const aliasesKey = "aliases"
+
+type alias struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+type aliasesByName []alias
+
+func (a aliasesByName) Len() int           { return len(a) }
+func (a aliasesByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a aliasesByName) Less(i, j int) bool { return a[i].Name < a[j].Name }
+
+// Alias creates the alias commands hierarchy.
+func Alias() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "alias",
+			Short: "alias commands",
+			Long:  "alias is used to define shortcuts for doctl commands",
+		},
+	}
+
+	// alias commands only touch the config file, so they are built without
+	// CmdBuilder to avoid requiring an access token.
+	cmd.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:   "set <name> <command>",
+			Short: "create or replace an alias",
+			Long:  "create or replace an alias, e.g. doctl alias set dls 'compute droplet list --format Name,PublicIPv4'",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunAliasSet(cmdNS(cmd), cfgFile, args), cmd)
+			},
+		},
+	})
+
+	cmd.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:     "list",
+			Aliases: []string{"ls"},
+			Short:   "list aliases",
+			Long:    "list aliases",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunAliasList(cmdNS(cmd), cfgFile, Writer), cmd)
+			},
+		},
+	})
+
+	cmd.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:     "delete <name>",
+			Aliases: []string{"rm"},
+			Short:   "delete an alias",
+			Long:    "delete an alias",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunAliasDelete(cmdNS(cmd), cfgFile, args), cmd)
+			},
+		},
+	})
+
+	return cmd
+}
+
+// RunAliasSet stores an alias in the config file at path.
+func RunAliasSet(ns, path string, args []string) error {
+	if len(args) != 2 {
+		return doctl.NewMissingArgsErr(ns)
+	}
+
+	name, command := args[0], strings.TrimSpace(args[1])
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if isBuiltinCommand(name) {
+		return fmt.Errorf("%q is a doctl command and can't be used as an alias", name)
+	}
+
+	words, err := splitCommandLine(command)
+	if err != nil {
+		return fmt.Errorf("invalid alias command: %v", err)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("alias command is empty")
+	}
+
+	aliases, err := readAliases(path)
+	if err != nil {
+		return err
+	}
+
+	aliases[name] = command
+	return writeAliases(path, aliases)
+}
+
+// RunAliasList lists the aliases in the config file at path.
+func RunAliasList(ns, path string, out io.Writer) error {
+	aliases, err := readAliases(path)
+	if err != nil {
+		return err
+	}
+
+	list := aliasList{}
+	for name, command := range aliases {
+		list = append(list, alias{Name: name, Command: command})
+	}
+	sort.Sort(aliasesByName(list))
+
+	d := &displayer{ns: ns, config: doctl.DoitConfig, item: &list, out: out}
+	return d.Display()
+}
+
+// RunAliasDelete removes an alias from the config file at path.
+func RunAliasDelete(ns, path string, args []string) error {
+	if len(args) != 1 {
+		return doctl.NewMissingArgsErr(ns)
+	}
+
+	aliases, err := readAliases(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := aliases[args[0]]; !ok {
+		return fmt.Errorf("alias %q does not exist", args[0])
+	}
+
+	delete(aliases, args[0])
+	return writeAliases(path, aliases)
+}
+
+func isBuiltinCommand(name string) bool {
+	for _, c := range DoitCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+
+	return name == "help"
+}
+
+// expandAlias replaces the first command word in args with the command it
+// is an alias for. Flags given before the command are kept in place.
+func expandAlias(args []string, aliases map[string]string) ([]string, error) {
+	i := commandWordIndex(args)
+	if i < 0 || isBuiltinCommand(args[i]) {
+		return args, nil
+	}
+
+	command, ok := aliases[args[i]]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(command)
+	if err != nil {
+		return nil, fmt.Errorf("alias %q: %v", args[i], err)
+	}
+
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, words...)
+	return append(expanded, args[i+1:]...), nil
+}
+
+// commandWordIndex returns the index of the first argument that is not a
+// root flag or a root flag's value, or -1 if there is none.
+func commandWordIndex(args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+
+		var name string
+		if strings.HasPrefix(arg, "--") {
+			name = arg[2:]
+		} else {
+			name = arg[len(arg)-1:]
+		}
+
+		if rootFlagTakesValue(name) {
+			i++
+		}
+	}
+
+	return -1
+}
+
+func rootFlagTakesValue(name string) bool {
+	takesValue := false
+	DoitCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Name == name || f.Shorthand == name {
+			takesValue = f.Value.Type() != "bool"
+		}
+	})
+
+	return takesValue
+}
+
+// configPathFromArgs returns the config file named by --config in args, or
+// the default config file.
+func configPathFromArgs(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+
+		switch {
+		case arg == "--config" || arg == "-c":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		case strings.HasPrefix(arg, "-c="):
+			return strings.TrimPrefix(arg, "-c=")
+		}
+	}
+
+	return filepath.Join(homeDir(), cfgFileName)
+}
+
+func readConfigFile(path string) (yaml.MapSlice, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return yaml.MapSlice{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ms yaml.MapSlice
+	if err := yaml.Unmarshal(b, &ms); err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+func readAliases(path string) (map[string]string, error) {
+	ms, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := map[string]string{}
+	for _, item := range ms {
+		if item.Key != aliasesKey {
+			continue
+		}
+
+		entries, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("%s in %s is not a map", aliasesKey, path)
+		}
+		for _, e := range entries {
+			aliases[fmt.Sprint(e.Key)] = fmt.Sprint(e.Value)
+		}
+	}
+
+	return aliases, nil
+}
+
+// writeAliases replaces the aliases in the config file at path, leaving the
+// rest of the file as it is.
+func writeAliases(path string, aliases map[string]string) error {
+	ms, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := yaml.MapSlice{}
+	for _, name := range names {
+		entries = append(entries, yaml.MapItem{Key: name, Value: aliases[name]})
+	}
+
+	found := false
+	for i := range ms {
+		if ms[i].Key == aliasesKey {
+			ms[i].Value = entries
+			found = true
+		}
+	}
+	if !found {
+		ms = append(ms, yaml.MapItem{Key: aliasesKey, Value: entries})
+	}
+
+	b, err := yaml.Marshal(ms)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0600)
+}
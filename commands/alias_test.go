@@ -0,0 +1,123 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"dls":     "compute droplet list --format 'Name,Public IPv4'",
+		"account": "compute droplet list",
+	}
+
+	cases := []struct {
+		in  []string
+		out []string
+	}{
+		{
+			in:  []string{"dls"},
+			out: []string{"compute", "droplet", "list", "--format", "Name,Public IPv4"},
+		},
+		{
+			in:  []string{"-o", "json", "--trace", "dls", "--no-header"},
+			out: []string{"-o", "json", "--trace", "compute", "droplet", "list", "--format", "Name,Public IPv4", "--no-header"},
+		},
+		{
+			in:  []string{"--output=json", "dls"},
+			out: []string{"--output=json", "compute", "droplet", "list", "--format", "Name,Public IPv4"},
+		},
+		{
+			in:  []string{"-o", "dls"},
+			out: []string{"-o", "dls"},
+		},
+		{
+			in:  []string{"account", "get"},
+			out: []string{"account", "get"},
+		},
+		{
+			in:  []string{"compute", "dls"},
+			out: []string{"compute", "dls"},
+		},
+	}
+
+	for _, c := range cases {
+		got, err := expandAlias(c.in, aliases)
+		assert.NoError(t, err)
+		assert.Equal(t, c.out, got, "%v", c.in)
+	}
+}
+
+func TestConfigPathFromArgs(t *testing.T) {
+	assert.Equal(t, "/tmp/a", configPathFromArgs([]string{"-c", "/tmp/a", "dls"}))
+	assert.Equal(t, "/tmp/b", configPathFromArgs([]string{"dls", "--config=/tmp/b"}))
+	assert.Equal(t, filepath.Join(homeDir(), cfgFileName), configPathFromArgs([]string{"dls", "--", "-c", "x"}))
+}
+
+func TestAliasSetAndDelete(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctl-alias")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("access-token: abc\n"), 0600))
+
+	err = RunAliasSet("alias.set", path, []string{"dls", "compute droplet list --format Name"})
+	assert.NoError(t, err)
+	err = RunAliasSet("alias.set", path, []string{"ils", "compute image list"})
+	assert.NoError(t, err)
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `access-token: abc
+aliases:
+  dls: compute droplet list --format Name
+  ils: compute image list
+`, string(b))
+
+	err = RunAliasDelete("alias.delete", path, []string{"dls"})
+	assert.NoError(t, err)
+
+	aliases, err := readAliases(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ils": "compute image list"}, aliases)
+
+	err = RunAliasDelete("alias.delete", path, []string{"dls"})
+	assert.Error(t, err)
+}
+
+func TestAliasSetInvalid(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "doctl-alias-never-written")
+
+	for _, args := range [][]string{
+		{"dls"},
+		{"compute", "droplet list"},
+		{"-x", "droplet list"},
+		{"dls", "  "},
+		{"dls", "droplet 'list"},
+	} {
+		err := RunAliasSet("alias.set", path, args)
+		assert.Error(t, err, "%v", args)
+	}
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
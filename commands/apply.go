@@ -0,0 +1,682 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"gopkg.in/yaml.v2"
+)
+
+// manifestInput is where a manifest is read from when its path is "-".
+var manifestInput io.Reader = os.Stdin
+
+// manifest describes the desired state of an account's resources.
+type manifest struct {
+	Volumes       []manifestVolume  `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+	Droplets      []manifestDroplet `yaml:"droplets,omitempty" json:"droplets,omitempty"`
+	Domains       []manifestDomain  `yaml:"domains,omitempty" json:"domains,omitempty"`
+	Firewalls     []interface{}     `yaml:"firewalls,omitempty" json:"firewalls,omitempty"`
+	LoadBalancers []interface{}     `yaml:"load_balancers,omitempty" json:"load_balancers,omitempty"`
+}
+
+type manifestVolume struct {
+	Name          string `yaml:"name" json:"name"`
+	Region        string `yaml:"region" json:"region"`
+	SizeGigaBytes int64  `yaml:"size_gigabytes" json:"size_gigabytes"`
+	Description   string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+type manifestDroplet struct {
+	Name              string   `yaml:"name" json:"name"`
+	Region            string   `yaml:"region" json:"region"`
+	Size              string   `yaml:"size" json:"size"`
+	Image             string   `yaml:"image" json:"image"`
+	SSHKeys           []string `yaml:"ssh_keys,omitempty" json:"ssh_keys,omitempty"`
+	Backups           bool     `yaml:"backups,omitempty" json:"backups,omitempty"`
+	IPv6              bool     `yaml:"ipv6,omitempty" json:"ipv6,omitempty"`
+	PrivateNetworking bool     `yaml:"private_networking,omitempty" json:"private_networking,omitempty"`
+	UserData          string   `yaml:"user_data,omitempty" json:"user_data,omitempty"`
+	Tags              []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Volumes           []string `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+}
+
+type manifestDomain struct {
+	Name      string           `yaml:"name" json:"name"`
+	IPAddress string           `yaml:"ip_address,omitempty" json:"ip_address,omitempty"`
+	Records   []manifestRecord `yaml:"records,omitempty" json:"records,omitempty"`
+}
+
+type manifestRecord struct {
+	Type     string `yaml:"type" json:"type"`
+	Name     string `yaml:"name" json:"name"`
+	Data     string `yaml:"data" json:"data"`
+	Priority int    `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Port     int    `yaml:"port,omitempty" json:"port,omitempty"`
+	Weight   int    `yaml:"weight,omitempty" json:"weight,omitempty"`
+}
+
+// Apply creates the apply command.
+func Apply() *Command {
+	cmd := CmdBuilder(nil, RunApply, "apply", "converge resources toward a manifest", Writer,
+		docCategories("apply"))
+	AddStringFlagP(cmd, doctl.ArgFile, "f", "", "Manifest file (- reads from stdin)", requiredOpt())
+	AddBoolFlag(cmd, doctl.ArgPrune, false, "Delete domain records which are not declared in the manifest")
+
+	return cmd
+}
+
+// Diff creates the diff command.
+func Diff() *Command {
+	cmd := CmdBuilder(nil, RunDiff, "diff", "show changes apply would make", Writer,
+		docCategories("apply"))
+	AddStringFlagP(cmd, doctl.ArgFile, "f", "", "Manifest file (- reads from stdin)", requiredOpt())
+	AddBoolFlag(cmd, doctl.ArgPrune, false, "Include deletion of domain records which are not declared in the manifest")
+
+	return cmd
+}
+
+// RunApply converges live resources toward a manifest.
+func RunApply(c *CmdConfig) error {
+	changes, err := planFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(c.Out, "no changes")
+		return nil
+	}
+
+	for _, pc := range changes {
+		fmt.Fprintln(c.Out, pc)
+
+		if pc.apply == nil {
+			continue
+		}
+
+		if err := pc.apply(); err != nil {
+			return fmt.Errorf("%s %s: %v", pc.kind, pc.name, err)
+		}
+	}
+
+	return nil
+}
+
+// RunDiff shows the changes which are needed to converge live resources
+// toward a manifest.
+func RunDiff(c *CmdConfig) error {
+	changes, err := planFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(c.Out, "no changes")
+		return nil
+	}
+
+	for _, pc := range changes {
+		fmt.Fprintln(c.Out, pc)
+	}
+
+	return nil
+}
+
+func planFromArgs(c *CmdConfig) ([]planChange, error) {
+	path, err := c.Doit.GetString(c.NS, doctl.ArgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, doctl.NewMissingArgsErr(c.NS)
+	}
+
+	prune, err := c.Doit.GetBool(c.NS, doctl.ArgPrune)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := readManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPlan(c, m, prune)
+}
+
+func readManifest(path string) (*manifest, error) {
+	var b []byte
+	var err error
+
+	if path == "-" {
+		b, err = ioutil.ReadAll(manifestInput)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %v", err)
+	}
+
+	if len(m.Firewalls) > 0 {
+		return nil, errors.New("firewalls are not supported by this version of doctl")
+	}
+
+	if len(m.LoadBalancers) > 0 {
+		return nil, errors.New("load balancers are not supported by this version of doctl")
+	}
+
+	for _, v := range m.Volumes {
+		if v.Name == "" || v.Region == "" || v.SizeGigaBytes <= 0 {
+			return nil, fmt.Errorf("volume %q requires a name, region, and size", v.Name)
+		}
+	}
+
+	for _, d := range m.Droplets {
+		if d.Name == "" || d.Region == "" || d.Size == "" || d.Image == "" {
+			return nil, fmt.Errorf("droplet %q requires a name, region, size, and image", d.Name)
+		}
+	}
+
+	for _, d := range m.Domains {
+		if d.Name == "" {
+			return nil, errors.New("domain requires a name")
+		}
+
+		for _, r := range d.Records {
+			if r.Type == "" {
+				return nil, fmt.Errorf("record %q in domain %q requires a type", r.Name, d.Name)
+			}
+		}
+	}
+
+	return &m, nil
+}
+
+// planChange is a single change needed to converge a resource. Changes
+// without an apply func are drift which can't be converged automatically.
+type planChange struct {
+	op     string
+	kind   string
+	name   string
+	detail string
+	apply  func() error
+}
+
+func (pc planChange) String() string {
+	s := fmt.Sprintf("%s %s %s", pc.op, pc.kind, pc.name)
+	if pc.detail != "" {
+		s += ": " + pc.detail
+	}
+
+	return s
+}
+
+func buildPlan(c *CmdConfig, m *manifest, prune bool) ([]planChange, error) {
+	var changes []planChange
+
+	if len(m.Volumes) > 0 {
+		vc, err := planVolumes(c, m.Volumes)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, vc...)
+	}
+
+	if len(m.Droplets) > 0 {
+		dc, err := planDroplets(c, m.Droplets)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, dc...)
+	}
+
+	if len(m.Domains) > 0 {
+		dc, err := planDomains(c, m.Domains, prune)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, dc...)
+	}
+
+	return changes, nil
+}
+
+func planVolumes(c *CmdConfig, declared []manifestVolume) ([]planChange, error) {
+	vs := c.Volumes()
+
+	list, err := vs.List()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]do.Volume{}
+	for _, v := range list {
+		byName[v.Name] = v
+	}
+
+	var changes []planChange
+	for _, mv := range declared {
+		mv := mv
+
+		v, ok := byName[mv.Name]
+		if !ok {
+			changes = append(changes, planChange{
+				op:     "+",
+				kind:   "volume",
+				name:   mv.Name,
+				detail: fmt.Sprintf("%s %d GiB", mv.Region, mv.SizeGigaBytes),
+				apply: func() error {
+					_, err := vs.CreateVolume(&godo.VolumeCreateRequest{
+						Name:          mv.Name,
+						Region:        mv.Region,
+						SizeGigaBytes: mv.SizeGigaBytes,
+						Description:   mv.Description,
+					})
+					return err
+				},
+			})
+			continue
+		}
+
+		if v.Region != nil && v.Region.Slug != mv.Region {
+			changes = append(changes, planChange{
+				op:     "!",
+				kind:   "volume",
+				name:   mv.Name,
+				detail: fmt.Sprintf("region is %s, manifest declares %s (not converged)", v.Region.Slug, mv.Region),
+			})
+		}
+
+		if v.SizeGigaBytes != mv.SizeGigaBytes {
+			changes = append(changes, planChange{
+				op:     "!",
+				kind:   "volume",
+				name:   mv.Name,
+				detail: fmt.Sprintf("size is %d GiB, manifest declares %d GiB (not converged)", v.SizeGigaBytes, mv.SizeGigaBytes),
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func planDroplets(c *CmdConfig, declared []manifestDroplet) ([]planChange, error) {
+	ds := c.Droplets()
+	ts := c.Tags()
+
+	list, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]do.Droplets{}
+	for _, d := range list {
+		byName[d.Name] = append(byName[d.Name], d)
+	}
+
+	var changes []planChange
+	for _, md := range declared {
+		md := md
+		live := byName[md.Name]
+
+		switch len(live) {
+		case 0:
+			changes = append(changes, planChange{
+				op:     "+",
+				kind:   "droplet",
+				name:   md.Name,
+				detail: fmt.Sprintf("%s %s %s", md.Region, md.Size, md.Image),
+				apply: func() error {
+					return createManifestDroplet(ds, ts, md)
+				},
+			})
+		case 1:
+			changes = append(changes, diffDroplet(ts, live[0], md)...)
+		default:
+			return nil, fmt.Errorf("there are %d droplets with the name %q", len(live), md.Name)
+		}
+	}
+
+	return changes, nil
+}
+
+func createManifestDroplet(ds do.DropletsService, ts do.TagsService, md manifestDroplet) error {
+	var image godo.DropletCreateImage
+	if i, err := strconv.Atoi(md.Image); err == nil {
+		image = godo.DropletCreateImage{ID: i}
+	} else {
+		image = godo.DropletCreateImage{Slug: md.Image}
+	}
+
+	dcr := &godo.DropletCreateRequest{
+		Name:              md.Name,
+		Region:            md.Region,
+		Size:              md.Size,
+		Image:             image,
+		Volumes:           extractVolumes(md.Volumes),
+		Backups:           md.Backups,
+		IPv6:              md.IPv6,
+		PrivateNetworking: md.PrivateNetworking,
+		SSHKeys:           extractSSHKeys(md.SSHKeys),
+		UserData:          md.UserData,
+	}
+
+	d, err := ds.Create(dcr, false)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range md.Tags {
+		trr := &godo.TagResourcesRequest{
+			Resources: []godo.Resource{
+				{ID: strconv.Itoa(d.ID), Type: godo.DropletResourceType},
+			},
+		}
+
+		if err := ts.TagResources(t, trr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func diffDroplet(ts do.TagsService, d do.Droplet, md manifestDroplet) []planChange {
+	var changes []planChange
+
+	drift := func(attr, live, declared string) {
+		changes = append(changes, planChange{
+			op:     "!",
+			kind:   "droplet",
+			name:   md.Name,
+			detail: fmt.Sprintf("%s is %s, manifest declares %s (not converged)", attr, live, declared),
+		})
+	}
+
+	if d.Region != nil && d.Region.Slug != md.Region {
+		drift("region", d.Region.Slug, md.Region)
+	}
+
+	if d.SizeSlug != "" && d.SizeSlug != md.Size {
+		drift("size", d.SizeSlug, md.Size)
+	}
+
+	if d.Image != nil && d.Image.Slug != md.Image && strconv.Itoa(d.Image.ID) != md.Image {
+		live := d.Image.Slug
+		if live == "" {
+			live = strconv.Itoa(d.Image.ID)
+		}
+		drift("image", live, md.Image)
+	}
+
+	// tags are only managed when they are declared.
+	if md.Tags == nil {
+		return changes
+	}
+
+	add := stringsMissing(md.Tags, d.Tags)
+	remove := stringsMissing(d.Tags, md.Tags)
+	if len(add) == 0 && len(remove) == 0 {
+		return changes
+	}
+
+	var detail []string
+	for _, t := range add {
+		detail = append(detail, "+"+t)
+	}
+	for _, t := range remove {
+		detail = append(detail, "-"+t)
+	}
+
+	resources := []godo.Resource{
+		{ID: strconv.Itoa(d.ID), Type: godo.DropletResourceType},
+	}
+
+	changes = append(changes, planChange{
+		op:     "~",
+		kind:   "droplet",
+		name:   md.Name,
+		detail: "tags " + strings.Join(detail, " "),
+		apply: func() error {
+			for _, t := range add {
+				if err := ts.TagResources(t, &godo.TagResourcesRequest{Resources: resources}); err != nil {
+					return err
+				}
+			}
+
+			for _, t := range remove {
+				if err := ts.UntagResources(t, &godo.UntagResourcesRequest{Resources: resources}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+
+	return changes
+}
+
+// stringsMissing returns the items in a which are not in b.
+func stringsMissing(a, b []string) []string {
+	seen := map[string]bool{}
+	for _, s := range b {
+		seen[s] = true
+	}
+
+	var out []string
+	for _, s := range a {
+		if !seen[s] {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+func planDomains(c *CmdConfig, declared []manifestDomain, prune bool) ([]planChange, error) {
+	ds := c.Domains()
+
+	list, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := map[string]bool{}
+	for _, d := range list {
+		exists[d.Name] = true
+	}
+
+	var changes []planChange
+	for _, md := range declared {
+		md := md
+
+		var live do.DomainRecords
+		if exists[md.Name] {
+			live, err = ds.Records(md.Name)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			changes = append(changes, planChange{
+				op:   "+",
+				kind: "domain",
+				name: md.Name,
+				apply: func() error {
+					_, err := ds.Create(&godo.DomainCreateRequest{
+						Name:      md.Name,
+						IPAddress: md.IPAddress,
+					})
+					return err
+				},
+			})
+
+			// creating a domain with an IP address also creates its apex record.
+			if md.IPAddress != "" {
+				live = do.DomainRecords{
+					{DomainRecord: &godo.DomainRecord{Type: "A", Name: "@", Data: md.IPAddress}},
+				}
+			}
+		}
+
+		changes = append(changes, diffRecords(ds, md.Name, live, md.Records, prune)...)
+	}
+
+	return changes, nil
+}
+
+func normalizeRecord(r manifestRecord) manifestRecord {
+	r.Type = strings.ToUpper(r.Type)
+	if r.Name == "" {
+		r.Name = "@"
+	}
+
+	return r
+}
+
+func liveRecord(r do.DomainRecord) manifestRecord {
+	return manifestRecord{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     r.Data,
+		Priority: r.Priority,
+		Port:     r.Port,
+		Weight:   r.Weight,
+	}
+}
+
+func recordEditRequest(r manifestRecord) *godo.DomainRecordEditRequest {
+	return &godo.DomainRecordEditRequest{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     r.Data,
+		Priority: r.Priority,
+		Port:     r.Port,
+		Weight:   r.Weight,
+	}
+}
+
+func diffRecords(ds do.DomainsService, domain string, live do.DomainRecords, declared []manifestRecord, prune bool) []planChange {
+	var changes []planChange
+
+	var remaining do.DomainRecords
+	for _, r := range live {
+		// SOA records are always managed by DigitalOcean.
+		if r.Type != "SOA" {
+			remaining = append(remaining, r)
+		}
+	}
+
+	var unmatched []manifestRecord
+	for _, r := range declared {
+		r = normalizeRecord(r)
+
+		found := -1
+		for i, lr := range remaining {
+			if liveRecord(lr) == r {
+				found = i
+				break
+			}
+		}
+
+		if found >= 0 {
+			remaining = append(remaining[:found], remaining[found+1:]...)
+			continue
+		}
+
+		unmatched = append(unmatched, r)
+	}
+
+	for _, r := range unmatched {
+		r := r
+		name := fmt.Sprintf("%s %s %s", domain, r.Type, r.Name)
+
+		found := -1
+		for i, lr := range remaining {
+			if lr.Type == r.Type && lr.Name == r.Name && lr.ID != 0 {
+				found = i
+				break
+			}
+		}
+
+		if found < 0 {
+			changes = append(changes, planChange{
+				op:     "+",
+				kind:   "record",
+				name:   name,
+				detail: r.Data,
+				apply: func() error {
+					_, err := ds.CreateRecord(domain, recordEditRequest(r))
+					return err
+				},
+			})
+			continue
+		}
+
+		lr := remaining[found]
+		remaining = append(remaining[:found], remaining[found+1:]...)
+
+		id := lr.ID
+		changes = append(changes, planChange{
+			op:     "~",
+			kind:   "record",
+			name:   name,
+			detail: fmt.Sprintf("%s -> %s", lr.Data, r.Data),
+			apply: func() error {
+				_, err := ds.EditRecord(domain, id, recordEditRequest(r))
+				return err
+			},
+		})
+	}
+
+	if !prune {
+		return changes
+	}
+
+	for _, lr := range remaining {
+		// apex NS records are managed by DigitalOcean.
+		if lr.ID == 0 || (lr.Type == "NS" && lr.Name == "@") {
+			continue
+		}
+
+		id := lr.ID
+		changes = append(changes, planChange{
+			op:     "-",
+			kind:   "record",
+			name:   fmt.Sprintf("%s %s %s", domain, lr.Type, lr.Name),
+			detail: lr.Data,
+			apply: func() error {
+				return ds.DeleteRecord(domain, id)
+			},
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testManifest = `
+droplets:
+  - name: a-droplet
+    region: test0
+    size: 1gb
+    image: "1"
+    tags: [web]
+  - name: new-droplet
+    region: dev0
+    size: 1gb
+    image: image
+domains:
+  - name: example.com
+    records:
+      - type: a
+        name: www
+        data: 2.2.2.2
+      - type: MX
+        name: "@"
+        data: mail.example.com
+        priority: 10
+`
+
+	testManifestRecords = do.DomainRecords{
+		{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "1.1.1.1"}},
+		{DomainRecord: &godo.DomainRecord{ID: 2, Type: "CNAME", Name: "old", Data: "example.com"}},
+		{DomainRecord: &godo.DomainRecord{ID: 3, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+	}
+)
+
+func writeTestManifest(t *testing.T, contents string) string {
+	f, err := ioutil.TempFile("", "doctl-manifest")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.WriteString(contents)
+	assert.NoError(t, err)
+
+	return f.Name()
+}
+
+func TestApply(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := writeTestManifest(t, testManifest)
+		defer os.Remove(path)
+
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.domains.On("List").Return(testDomainList, nil)
+		tm.domains.On("Records", "example.com").Return(testManifestRecords, nil)
+
+		trr := &godo.TagResourcesRequest{
+			Resources: []godo.Resource{{ID: "1", Type: godo.DropletResourceType}},
+		}
+		tm.tags.On("TagResources", "web", trr).Return(nil)
+
+		dcr := &godo.DropletCreateRequest{
+			Name:    "new-droplet",
+			Region:  "dev0",
+			Size:    "1gb",
+			Image:   godo.DropletCreateImage{Slug: "image"},
+			SSHKeys: []godo.DropletCreateSSHKey{},
+		}
+		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
+
+		edit := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "2.2.2.2"}
+		tm.domains.On("EditRecord", "example.com", 1, edit).Return(&testRecord, nil)
+
+		create := &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com", Priority: 10}
+		tm.domains.On("CreateRecord", "example.com", create).Return(&testRecord, nil)
+
+		tm.domains.On("DeleteRecord", "example.com", 2).Return(nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+		config.Doit.Set(config.NS, doctl.ArgPrune, true)
+
+		err := RunApply(config)
+		assert.NoError(t, err)
+
+		expected := []string{
+			"~ droplet a-droplet: tags +web",
+			"+ droplet new-droplet: dev0 1gb image",
+			"~ record example.com A www: 1.1.1.1 -> 2.2.2.2",
+			"+ record example.com MX @: mail.example.com",
+			"- record example.com CNAME old: example.com",
+		}
+		assert.Equal(t, strings.Join(expected, "\n")+"\n", buf.String())
+	})
+}
+
+func TestDiff(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := writeTestManifest(t, testManifest)
+		defer os.Remove(path)
+
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.domains.On("List").Return(testDomainList, nil)
+		tm.domains.On("Records", "example.com").Return(testManifestRecords, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+
+		err := RunDiff(config)
+		assert.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, "+ droplet new-droplet")
+		assert.NotContains(t, out, "CNAME")
+	})
+}
+
+func TestDiffNewDomain(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := writeTestManifest(t, `
+domains:
+  - name: new.example.com
+    ip_address: 1.2.3.4
+    records:
+      - type: A
+        name: "@"
+        data: 1.2.3.4
+`)
+		defer os.Remove(path)
+
+		tm.domains.On("List").Return(testDomainList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+
+		err := RunDiff(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "+ domain new.example.com\n", buf.String())
+	})
+}
+
+func TestApplyVolumes(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := writeTestManifest(t, `
+volumes:
+  - name: test-volume
+    region: atlantis
+    size_gigabytes: 200
+  - name: new-volume
+    region: nyc1
+    size_gigabytes: 10
+`)
+		defer os.Remove(path)
+
+		tm.volumes.On("List").Return([]do.Volume{testVolume}, nil)
+
+		vcr := &godo.VolumeCreateRequest{Name: "new-volume", Region: "nyc1", SizeGigaBytes: 10}
+		tm.volumes.On("CreateVolume", vcr).Return(&testVolume, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+
+		err := RunApply(config)
+		assert.NoError(t, err)
+
+		expected := []string{
+			"! volume test-volume: size is 100 GiB, manifest declares 200 GiB (not converged)",
+			"+ volume new-volume: nyc1 10 GiB",
+		}
+		assert.Equal(t, strings.Join(expected, "\n")+"\n", buf.String())
+	})
+}
+
+func TestApplyUnsupportedResources(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := writeTestManifest(t, "load_balancers:\n  - name: lb\n")
+		defer os.Remove(path)
+
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+
+		err := RunApply(config)
+		assert.Error(t, err)
+	})
+}
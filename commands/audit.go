@@ -0,0 +1,178 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/viper"
+)
+
+// auditRedacted replaces the values of sensitive flags in the audit log.
+const auditRedacted = "REDACTED"
+
+// auditSecretFlags are flags whose values are never written to the audit log.
+var auditSecretFlags = map[string]bool{
+	"access-token": true,
+	"t":            true,
+	"user-data":    true,
+}
+
+// auditArgs are the arguments recorded for the running command.
+var auditArgs = os.Args[1:]
+
+type auditRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Status int    `json:"status"`
+}
+
+type auditRecord struct {
+	Time       time.Time      `json:"time"`
+	Command    string         `json:"command"`
+	Args       []string       `json:"args"`
+	Requests   []auditRequest `json:"requests"`
+	DurationMS int64          `json:"duration_ms"`
+	Error      string         `json:"error,omitempty"`
+}
+
+var audit struct {
+	mu        sync.Mutex
+	recording bool
+	requests  []auditRequest
+}
+
+// auditObserver records the API requests made while a command is being
+// audited. It is registered with the client's request observers, so it
+// sees every request alongside any other observer.
+var auditObserver = do.RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+	return req, func(resp *http.Response, err error) {
+		audit.mu.Lock()
+		defer audit.mu.Unlock()
+
+		if !audit.recording {
+			return
+		}
+
+		ar := auditRequest{Method: req.Method, URL: req.URL.String()}
+		if resp != nil {
+			ar.Status = resp.StatusCode
+		}
+		audit.requests = append(audit.requests, ar)
+	}
+})
+
+// auditPreRun starts recording the API requests made by a command when an
+// audit log has been configured.
+func auditPreRun(c *CmdConfig) error {
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+
+	audit.recording = viper.GetString(doctl.ArgAuditLog) != ""
+	audit.requests = nil
+
+	return nil
+}
+
+// auditPostRun appends a record of the command to the audit log.
+func auditPostRun(c *CmdConfig, elapsed time.Duration, err error) {
+	path := viper.GetString(doctl.ArgAuditLog)
+	if path == "" {
+		return
+	}
+
+	audit.mu.Lock()
+	rec := auditRecord{
+		Time:       time.Now().UTC(),
+		Command:    c.NS,
+		Args:       redactArgs(auditArgs),
+		Requests:   audit.requests,
+		DurationMS: int64(elapsed / time.Millisecond),
+	}
+	audit.mu.Unlock()
+
+	if rec.Requests == nil {
+		rec.Requests = []auditRequest{}
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	if werr := appendAuditRecord(path, &rec); werr != nil {
+		warn(fmt.Sprintf("unable to write audit log: %v", werr))
+	}
+}
+
+func appendAuditRecord(path string, rec *auditRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// redactArgs returns a copy of args with the values of secret flags replaced.
+func redactArgs(args []string) []string {
+	out := make([]string, len(args))
+	redactNext := false
+
+	for i, arg := range args {
+		if redactNext {
+			out[i] = auditRedacted
+			redactNext = false
+			continue
+		}
+
+		out[i] = arg
+		if !strings.HasPrefix(arg, "-") || arg == "--" {
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if !strings.HasPrefix(arg, "--") && len(name) > 1 && auditSecretFlags[name[:1]] {
+			out[i] = arg[:2] + auditRedacted
+			continue
+		}
+
+		if idx := strings.Index(name, "="); idx >= 0 {
+			if auditSecretFlags[name[:idx]] {
+				out[i] = arg[:len(arg)-len(name)+idx+1] + auditRedacted
+			}
+			continue
+		}
+
+		redactNext = auditSecretFlags[name]
+	}
+
+	return out
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactArgs(t *testing.T) {
+	cases := []struct {
+		in  []string
+		out []string
+	}{
+		{
+			in:  []string{"compute", "droplet", "list"},
+			out: []string{"compute", "droplet", "list"},
+		},
+		{
+			in:  []string{"-t", "secret", "account", "get"},
+			out: []string{"-t", "REDACTED", "account", "get"},
+		},
+		{
+			in:  []string{"--access-token", "secret", "-o", "json"},
+			out: []string{"--access-token", "REDACTED", "-o", "json"},
+		},
+		{
+			in:  []string{"--access-token=secret", "-tsecret"},
+			out: []string{"--access-token=REDACTED", "-tREDACTED"},
+		},
+		{
+			in:  []string{"droplet", "create", "--user-data", "#!/bin/sh", "--size", "512mb"},
+			out: []string{"droplet", "create", "--user-data", "REDACTED", "--size", "512mb"},
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.out, redactArgs(c.in))
+	}
+}
+
+type auditTransport struct{}
+
+func (auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"account":{"uuid":"abc"}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctl-audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	viper.Set(doctl.ArgAuditLog, path)
+	viper.Set("access-token", "secret")
+	defer func() {
+		viper.Set(doctl.ArgAuditLog, "")
+		viper.Set("access-token", "")
+	}()
+
+	ogArgs := auditArgs
+	auditArgs = []string{"-t", "secret", "account", "get"}
+	defer func() { auditArgs = ogArgs }()
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		lc := doctl.NewLiveConfigWithHTTPClient(&http.Client{Transport: auditTransport{}})
+		lc.AddRequestObserver(auditObserver)
+		config.Doit = lc
+
+		assert.NoError(t, auditPreRun(config))
+
+		client, err := config.Doit.GetGodoClient(false)
+		assert.NoError(t, err)
+		// other users of godo's completion callback don't displace the log.
+		client.OnRequestCompleted(func(*http.Request, *http.Response) {})
+		_, _, err = client.Account.Get()
+		assert.NoError(t, err)
+
+		auditPostRun(config, 1500*time.Millisecond, errors.New("boom"))
+	})
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 1)
+
+	var rec auditRecord
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &rec))
+	assert.Equal(t, "test", rec.Command)
+	assert.Equal(t, []string{"-t", "REDACTED", "account", "get"}, rec.Args)
+	assert.Equal(t, int64(1500), rec.DurationMS)
+	assert.Equal(t, "boom", rec.Error)
+	assert.Equal(t, []auditRequest{
+		{Method: "GET", URL: "https://api.digitalocean.com/v2/account", Status: 200},
+	}, rec.Requests)
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	}
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		assert.NoError(t, auditPreRun(config))
+		auditPostRun(config, time.Second, nil)
+	})
+}
@@ -72,6 +72,9 @@ func Auth() *Command {
 	}
 
 	CmdBuilder(cmd, RunAuthLogin, "login", "login to DigitalOcean account", Writer, docCategories("account"))
+	CmdBuilder(cmd, RunAuthToken, "token", "print the configured access token", Writer, docCategories("account"))
+	CmdBuilder(cmd, RunAuthGitCredential, "git-credential <get|store|erase>", "git credential helper returning the access token", Writer,
+		docCategories("account"))
 
 	return cmd
 }
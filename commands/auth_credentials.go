@@ -0,0 +1,126 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+)
+
+// credentialInput is where git-credential reads its request from.
+var credentialInput io.Reader = os.Stdin
+
+// credentialUsername is the username returned with the token. The API
+// ignores it, but git and registries require one.
+const credentialUsername = "doctl"
+
+// RunAuthToken prints the access token doctl is configured with, so other
+// tools can use it without reading doctl's config themselves.
+func RunAuthToken(c *CmdConfig) error {
+	token, err := authToken(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.Out, token)
+	return err
+}
+
+// RunAuthGitCredential implements the git credential helper protocol. With
+// get, it answers https requests for DigitalOcean hosts with the configured
+// token. Tokens are managed by doctl, so store and erase do nothing.
+func RunAuthGitCredential(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	switch c.Args[0] {
+	case "get":
+	case "store", "erase":
+		// consume the request so the caller doesn't see a broken pipe.
+		_, err := readCredentialRequest(credentialInput)
+		return err
+	default:
+		return fmt.Errorf("unknown credential operation %q", c.Args[0])
+	}
+
+	req, err := readCredentialRequest(credentialInput)
+	if err != nil {
+		return err
+	}
+
+	// stay silent for other hosts so git asks the next helper.
+	if host := req["host"]; host != "" && !isDigitalOceanHost(host) {
+		return nil
+	}
+
+	// never hand the token out over a cleartext protocol.
+	if proto := req["protocol"]; proto != "" && proto != "https" {
+		return nil
+	}
+
+	token, err := authToken(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Out, "username=%s\npassword=%s\n", credentialUsername, token)
+	return err
+}
+
+func authToken(c *CmdConfig) (string, error) {
+	token, err := c.Doit.GetString(doctl.NSRoot, "access-token")
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("access token is required")
+	}
+
+	return token, nil
+}
+
+// readCredentialRequest reads key=value lines up to a blank line or EOF.
+func readCredentialRequest(r io.Reader) (map[string]string, error) {
+	req := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid credential request line %q", line)
+		}
+		req[parts[0]] = parts[1]
+	}
+
+	return req, scanner.Err()
+}
+
+func isDigitalOceanHost(host string) bool {
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+
+	return host == "digitalocean.com" || strings.HasSuffix(host, ".digitalocean.com")
+}
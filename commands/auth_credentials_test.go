@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/stretchr/testify/assert"
+)
+
+func withCredentialInput(input string, fn func()) {
+	ogInput := credentialInput
+	credentialInput = strings.NewReader(input)
+	defer func() { credentialInput = ogInput }()
+
+	fn()
+}
+
+func TestRunAuthToken(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(doctl.NSRoot, "access-token", "secret")
+
+		err := RunAuthToken(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret\n", buf.String())
+	})
+}
+
+func TestRunAuthTokenMissing(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		err := RunAuthToken(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestRunAuthGitCredential(t *testing.T) {
+	cases := []struct {
+		op, input, out string
+	}{
+		{op: "get", input: "protocol=https\nhost=registry.digitalocean.com\n\n", out: "username=doctl\npassword=secret\n"},
+		{op: "get", input: "protocol=https\nhost=api.digitalocean.com:443\n", out: "username=doctl\npassword=secret\n"},
+		{op: "get", input: "protocol=https\nhost=github.com\n\n", out: ""},
+		{op: "get", input: "protocol=http\nhost=registry.digitalocean.com\n\n", out: ""},
+		{op: "get", input: "host=registry.digitalocean.com\n\n", out: "username=doctl\npassword=secret\n"},
+		{op: "store", input: "protocol=https\nhost=registry.digitalocean.com\npassword=x\n\n", out: ""},
+		{op: "erase", input: "", out: ""},
+	}
+
+	for _, c := range cases {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			var buf bytes.Buffer
+			config.Out = &buf
+			config.Args = []string{c.op}
+			config.Doit.Set(doctl.NSRoot, "access-token", "secret")
+
+			withCredentialInput(c.input, func() {
+				err := RunAuthGitCredential(config)
+				assert.NoError(t, err)
+			})
+			assert.Equal(t, c.out, buf.String(), "%s %q", c.op, c.input)
+		})
+	}
+}
+
+func TestRunAuthGitCredentialInvalid(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = []string{"fetch"}
+		err := RunAuthGitCredential(config)
+		assert.Error(t, err)
+
+		config.Args = []string{"get"}
+		withCredentialInput("not a pair\n", func() {
+			err = RunAuthGitCredential(config)
+		})
+		assert.Error(t, err)
+	})
+}
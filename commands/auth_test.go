@@ -29,7 +29,7 @@ import (
 func TestAuthCommand(t *testing.T) {
 	cmd := Auth()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "login")
+	assertCommandNames(t, cmd, "git-credential", "login", "token")
 }
 
 func TestAuth_retrieveCredentials(t *testing.T) {
@@ -0,0 +1,246 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// batchInput is where commands are read from when no file is given.
+var batchInput io.Reader = os.Stdin
+
+// batchFailed is raised through errAction when a batched command fails, so
+// the batch can carry on instead of exiting.
+type batchFailed struct{}
+
+// Batch creates the batch command.
+func Batch() *Command {
+	cmd := CmdBuilder(nil, RunBatch, "batch", "run doctl commands read from a file or stdin", Writer,
+		docCategories("batch"))
+	AddStringFlagP(cmd, doctl.ArgFile, "f", "", "File of commands, one per line (default is stdin)")
+	AddBoolFlag(cmd, doctl.ArgContinueOnError, false, "Run the remaining commands after one fails")
+
+	return cmd
+}
+
+// RunBatch runs doctl commands, one per line, in this process so they share
+// the configuration and API client. Blank lines and lines starting with #
+// are skipped, and a leading "doctl" is optional.
+func RunBatch(c *CmdConfig) error {
+	path, err := c.Doit.GetString(c.NS, doctl.ArgFile)
+	if err != nil {
+		return err
+	}
+
+	keepGoing, err := c.Doit.GetBool(c.NS, doctl.ArgContinueOnError)
+	if err != nil {
+		return err
+	}
+
+	r := batchInput
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return runBatch(DoitCmd.Command, r, keepGoing)
+}
+
+func runBatch(root *cobra.Command, r io.Reader, keepGoing bool) error {
+	var lines [][]string
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		args, err := splitCommandLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", n, err)
+		}
+		if len(args) > 0 && args[0] == root.Name() {
+			args = args[1:]
+		}
+		if len(args) > 0 && args[0] == "batch" {
+			return fmt.Errorf("line %d: batch cannot be nested", n)
+		}
+
+		lines = append(lines, args)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, args := range lines {
+		if err := runBatchLine(root, args); err != nil {
+			failed++
+			if !keepGoing {
+				return err
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d commands failed", failed, len(lines))
+	}
+
+	return nil
+}
+
+// runBatchLine executes root with args, resetting every flag first so
+// nothing leaks from the previous line.
+func runBatchLine(root *cobra.Command, args []string) (err error) {
+	ogErrAction := errAction
+	errAction = func() {
+		panic(batchFailed{})
+	}
+
+	defer func() {
+		errAction = ogErrAction
+
+		if r := recover(); r != nil {
+			if _, ok := r.(batchFailed); !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%s failed", strings.Join(args, " "))
+		}
+	}()
+
+	if err := resetFlags(root); err != nil {
+		return err
+	}
+
+	root.SetArgs(args)
+	_, err = root.ExecuteC()
+	return err
+}
+
+// resetFlags returns every flag set on cmd and its children to its default.
+func resetFlags(cmd *cobra.Command) error {
+	var err error
+	reset := func(f *pflag.Flag) {
+		if err != nil || !f.Changed {
+			return
+		}
+		err = resetFlag(f)
+	}
+
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, child := range cmd.Commands() {
+		if cerr := resetFlags(child); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+func resetFlag(f *pflag.Flag) error {
+	f.Changed = false
+
+	if f.Value.Type() != "stringSlice" {
+		return f.Value.Set(f.DefValue)
+	}
+
+	// Setting a string slice appends once it has been changed, so it is
+	// replaced with a fresh value holding the defaults. Flags are always
+	// read through their *pflag.Flag, so nothing holds on to the old value.
+	var defaults []string
+	if def := strings.Trim(f.DefValue, "[]"); def != "" {
+		var err error
+		defaults, err = csv.NewReader(strings.NewReader(def)).Read()
+		if err != nil {
+			return err
+		}
+	}
+
+	fs := pflag.NewFlagSet(f.Name, pflag.ContinueOnError)
+	fs.StringSlice(f.Name, defaults, f.Usage)
+	f.Value = fs.Lookup(f.Name).Value
+
+	return nil
+}
+
+// splitCommandLine splits a line into arguments the way a shell would for
+// simple quoting: single quotes are literal, double quotes allow backslash
+// escapes, and unquoted backslashes escape the next character.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var cur []rune
+	inArg := false
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, string(cur))
+				cur = cur[:0]
+				inArg = false
+			}
+		case r == '\\':
+			i++
+			if i == len(runes) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			cur = append(cur, runes[i])
+			inArg = true
+		case r == '\'' || r == '"':
+			quote := r
+			inArg = true
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == quote {
+					closed = true
+					break
+				}
+				if quote == '"' && runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				cur = append(cur, runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated %c quote", quote)
+			}
+		default:
+			cur = append(cur, r)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, string(cur))
+	}
+
+	return args, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	cases := []struct {
+		in  string
+		out []string
+	}{
+		{in: "compute droplet list", out: []string{"compute", "droplet", "list"}},
+		{in: "  a   b\tc ", out: []string{"a", "b", "c"}},
+		{in: `tag --tag-name 'web servers'`, out: []string{"tag", "--tag-name", "web servers"}},
+		{in: `x --format "ID,Name" ""`, out: []string{"x", "--format", "ID,Name", ""}},
+		{in: `say "a \"b\"" c\ d`, out: []string{"say", `a "b"`, "c d"}},
+	}
+
+	for _, c := range cases {
+		got, err := splitCommandLine(c.in)
+		assert.NoError(t, err, c.in)
+		assert.Equal(t, c.out, got, c.in)
+	}
+
+	for _, in := range []string{`a "b`, `a 'b`, `a \`} {
+		_, err := splitCommandLine(in)
+		assert.Error(t, err, in)
+	}
+}
+
+type batchRecorder struct {
+	calls []string
+}
+
+func newBatchRoot(rec *batchRecorder) *cobra.Command {
+	root := &cobra.Command{Use: "doctl"}
+
+	echo := &cobra.Command{
+		Use: "echo",
+		Run: func(cmd *cobra.Command, args []string) {
+			name, _ := cmd.Flags().GetString("name")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			if fail, _ := cmd.Flags().GetBool("fail"); fail {
+				checkErr(assert.AnError)
+				return
+			}
+			rec.calls = append(rec.calls, name+"|"+strings.Join(tags, ",")+"|"+strings.Join(args, ","))
+		},
+	}
+	echo.Flags().String("name", "none", "")
+	echo.Flags().StringSlice("tag", []string{"default"}, "")
+	echo.Flags().Bool("fail", false, "")
+	root.AddCommand(echo)
+
+	return root
+}
+
+func TestRunBatch(t *testing.T) {
+	rec := &batchRecorder{}
+	input := strings.NewReader(`
+# comment
+doctl echo --name first --tag a --tag b x
+echo y
+echo --tag c
+`)
+
+	err := runBatch(newBatchRoot(rec), input, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"first|a,b|x",
+		"none|default|y",
+		"none|c|",
+	}, rec.calls)
+}
+
+func TestRunBatchFailure(t *testing.T) {
+	input := `echo one
+echo --fail
+echo two
+`
+
+	rec := &batchRecorder{}
+	err := runBatch(newBatchRoot(rec), strings.NewReader(input), false)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"none|default|one"}, rec.calls)
+
+	rec = &batchRecorder{}
+	err = runBatch(newBatchRoot(rec), strings.NewReader(input), true)
+	assert.EqualError(t, err, "1 of 3 commands failed")
+	assert.Equal(t, []string{"none|default|one", "none|default|two"}, rec.calls)
+}
+
+func TestRunBatchRejectsNesting(t *testing.T) {
+	err := runBatch(newBatchRoot(&batchRecorder{}), strings.NewReader("batch -f x\n"), false)
+	assert.Error(t, err)
+}
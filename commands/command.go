@@ -27,6 +27,9 @@ type Command struct {
 
 	childCommands []*Command
 	IsIndex       bool
+
+	preRunHooks  []PreRunHook
+	postRunHooks []PostRunHook
 }
 
 // AddCommand adds child commands and adds child commands for cobra as well.
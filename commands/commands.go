@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/digitalocean/doctl"
+	"github.com/fatih/color"
 )
 
 // Displayable is a displable entity. These are used for printing results.
@@ -31,6 +32,12 @@ type Displayable interface {
 	JSON(io.Writer) error
 }
 
+// wideDisplayable is implemented by items which show extra columns with
+// --output wide.
+type wideDisplayable interface {
+	WideCols() []string
+}
+
 type displayer struct {
 	ns     string
 	config doctl.Config
@@ -48,16 +55,36 @@ func (d *displayer) Display() error {
 		output = "text"
 	}
 
+	quiet, err := doctl.DoitConfig.GetBool(doctl.NSRoot, doctl.ArgQuiet)
+	if err != nil {
+		return err
+	}
+
 	switch output {
 	case "json":
 		return d.item.JSON(d.out)
-	case "text":
+	case "text", "wide":
+		if quiet {
+			return displayIDs(d.item, d.out)
+		}
+
 		cols, err := handleColumns(d.ns, d.config)
 		if err != nil {
 			return err
 		}
 
-		return displayText(d.item, d.out, cols)
+		if wd, ok := d.item.(wideDisplayable); ok && output == "wide" && (len(cols) == 0 || cols[0] == "") {
+			cols = wd.WideCols()
+		}
+
+		out, terminal := colorOutput(d.out)
+
+		var theme statusTheme
+		if terminal && !color.NoColor {
+			theme = loadTheme()
+		}
+
+		return displayText(d.item, out, cols, theme)
 	default:
 		return fmt.Errorf("unknown output type")
 	}
@@ -79,7 +106,26 @@ func writeJSON(item interface{}, w io.Writer) error {
 	return err
 }
 
-func displayText(item Displayable, out io.Writer, includeCols []string) error {
+// displayIDs prints the first column of each item, which holds its
+// identifier.
+func displayIDs(item Displayable, out io.Writer) error {
+	cols := item.Cols()
+	if len(cols) == 0 {
+		return nil
+	}
+
+	for _, r := range item.KV() {
+		if _, err := fmt.Fprintln(out, r[cols[0]]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// displayText writes item as a table. When theme is not nil, values in
+// themed columns are colored by status.
+func displayText(item Displayable, out io.Writer, includeCols []string, theme statusTheme) error {
 	w := newTabWriter(out)
 
 	cols := item.Cols()
@@ -95,6 +141,10 @@ func displayText(item Displayable, out io.Writer, includeCols []string) error {
 				return fmt.Errorf("unknown column %q", k)
 			}
 
+			if theme != nil && themedColumns[k] {
+				col = color.New(colorDefault).SprintFunc()(col)
+			}
+
 			headers = append(headers, col)
 		}
 		fmt.Fprintln(w, strings.Join(headers, "\t"))
@@ -106,6 +156,9 @@ func displayText(item Displayable, out io.Writer, includeCols []string) error {
 
 		for _, col := range cols {
 			v := r[col]
+			if theme != nil && themedColumns[col] {
+				v = theme.colorize(v)
+			}
 
 			values = append(values, v)
 
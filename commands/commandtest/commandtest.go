@@ -0,0 +1,202 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package commandtest provides helpers for unit testing doctl commands. It
+// builds CmdConfigs wired to the generated service mocks, so commands can be
+// exercised without talking to the DigitalOcean API.
+package commandtest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands"
+	"github.com/digitalocean/doctl/do"
+	domocks "github.com/digitalocean/doctl/do/mocks"
+	"github.com/digitalocean/doctl/pkg/runner"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mocks holds the service mocks a test CmdConfig is wired to.
+type Mocks struct {
+	Keys              domocks.KeysService
+	Sizes             domocks.SizesService
+	Regions           domocks.RegionsService
+	Images            domocks.ImagesService
+	ImageActions      domocks.ImageActionsService
+	FloatingIPs       domocks.FloatingIPsService
+	FloatingIPActions domocks.FloatingIPActionsService
+	Droplets          domocks.DropletsService
+	DropletActions    domocks.DropletActionsService
+	Domains           domocks.DomainsService
+	Volumes           domocks.VolumesService
+	VolumeActions     domocks.VolumeActionsService
+	Actions           domocks.ActionsService
+	Account           domocks.AccountService
+	Tags              domocks.TagsService
+}
+
+// AssertExpectations asserts the expectations of every mock, reporting
+// failures from all of them rather than stopping at the first.
+func (m *Mocks) AssertExpectations(t mock.TestingT) bool {
+	mocks := []interface {
+		AssertExpectations(mock.TestingT) bool
+	}{
+		&m.Account,
+		&m.Actions,
+		&m.Domains,
+		&m.DropletActions,
+		&m.Droplets,
+		&m.FloatingIPActions,
+		&m.FloatingIPs,
+		&m.ImageActions,
+		&m.Images,
+		&m.Regions,
+		&m.Sizes,
+		&m.Keys,
+		&m.Tags,
+		&m.Volumes,
+		&m.VolumeActions,
+	}
+
+	ok := true
+	for _, mk := range mocks {
+		if !mk.AssertExpectations(t) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// Config is an implementation of doctl.Config backed by its own viper
+// instance, so tests do not leak configuration into each other.
+type Config struct {
+	SSHFn func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner
+	v     *viper.Viper
+}
+
+var _ doctl.Config = &Config{}
+
+// NewConfig creates a Config whose SSH runner is a doctl.MockRunner.
+func NewConfig() *Config {
+	return &Config{
+		SSHFn: func(u, h, kp string, p int, opts ssh.Options) runner.Runner {
+			return &doctl.MockRunner{}
+		},
+		v: viper.New(),
+	}
+}
+
+// GetGodoClient returns an empty godo client.
+func (c *Config) GetGodoClient(trace bool) (*godo.Client, error) {
+	return &godo.Client{}, nil
+}
+
+// SSH returns the runner built by SSHFn.
+func (c *Config) SSH(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+	return c.SSHFn(user, host, keyPath, port, opts)
+}
+
+// Set sets a config key.
+func (c *Config) Set(ns, key string, val interface{}) {
+	c.v.Set(nskey(ns, key), val)
+}
+
+// GetString returns a config value as a string.
+func (c *Config) GetString(ns, key string) (string, error) {
+	return c.v.GetString(nskey(ns, key)), nil
+}
+
+// GetBool returns a config value as a bool.
+func (c *Config) GetBool(ns, key string) (bool, error) {
+	return c.v.GetBool(nskey(ns, key)), nil
+}
+
+// GetInt returns a config value as an int.
+func (c *Config) GetInt(ns, key string) (int, error) {
+	return c.v.GetInt(nskey(ns, key)), nil
+}
+
+// GetStringSlice returns a config value as a string slice.
+func (c *Config) GetStringSlice(ns, key string) ([]string, error) {
+	return c.v.GetStringSlice(nskey(ns, key)), nil
+}
+
+func nskey(ns, key string) string {
+	return fmt.Sprintf("%s-%s", ns, key)
+}
+
+// NewCmdConfig creates a CmdConfig in namespace ns whose services are the
+// mocks in m, and whose output is written to out.
+func NewCmdConfig(ns string, cfg doctl.Config, m *Mocks, out *bytes.Buffer) *commands.CmdConfig {
+	return &commands.CmdConfig{
+		NS:   ns,
+		Doit: cfg,
+		Out:  out,
+
+		Keys:              func() do.KeysService { return &m.Keys },
+		Sizes:             func() do.SizesService { return &m.Sizes },
+		Regions:           func() do.RegionsService { return &m.Regions },
+		Images:            func() do.ImagesService { return &m.Images },
+		ImageActions:      func() do.ImageActionsService { return &m.ImageActions },
+		FloatingIPs:       func() do.FloatingIPsService { return &m.FloatingIPs },
+		FloatingIPActions: func() do.FloatingIPActionsService { return &m.FloatingIPActions },
+		Droplets:          func() do.DropletsService { return &m.Droplets },
+		DropletActions:    func() do.DropletActionsService { return &m.DropletActions },
+		Domains:           func() do.DomainsService { return &m.Domains },
+		Actions:           func() do.ActionsService { return &m.Actions },
+		Account:           func() do.AccountService { return &m.Account },
+		Tags:              func() do.TagsService { return &m.Tags },
+		Volumes:           func() do.VolumesService { return &m.Volumes },
+		VolumeActions:     func() do.VolumeActionsService { return &m.VolumeActions },
+	}
+}
+
+// Case is passed to the function given to Run.
+type Case struct {
+	// Config is the command configuration to pass to a CmdRunner.
+	Config *commands.CmdConfig
+	// Mocks are the services Config is wired to.
+	Mocks *Mocks
+	// Out captures everything the command displays.
+	Out *bytes.Buffer
+}
+
+// Run builds a CmdConfig wired to fresh mocks and calls fn with it. While fn
+// runs, doctl.DoitConfig is replaced with the test configuration. When fn
+// returns, the expectations of all mocks are asserted.
+func Run(t *testing.T, fn func(tc *Case)) {
+	ogConfig := doctl.DoitConfig
+	defer func() {
+		doctl.DoitConfig = ogConfig
+	}()
+
+	cfg := NewConfig()
+	doctl.DoitConfig = cfg
+
+	tc := &Case{
+		Mocks: &Mocks{},
+		Out:   &bytes.Buffer{},
+	}
+	tc.Config = NewCmdConfig("test", cfg, tc.Mocks, tc.Out)
+
+	fn(tc)
+
+	tc.Mocks.AssertExpectations(t)
+}
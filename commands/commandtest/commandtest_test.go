@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commandtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, func(tc *Case) {
+		assert.Equal(t, tc.Config.Doit, doctl.DoitConfig)
+
+		list := do.Droplets{
+			{Droplet: &godo.Droplet{ID: 1, Name: "one", Image: &godo.Image{}, Region: &godo.Region{}}},
+		}
+		tc.Mocks.Droplets.On("List").Return(list, nil)
+
+		tc.Config.Doit.Set(tc.Config.NS, doctl.ArgFormat, "ID,Name")
+		tc.Config.Doit.Set(tc.Config.NS, doctl.ArgNoHeader, true)
+
+		err := commands.RunDropletList(tc.Config)
+		assert.NoError(t, err)
+		assert.Equal(t, "1 one", strings.Join(strings.Fields(tc.Out.String()), " "))
+	})
+}
+
+func TestConfig(t *testing.T) {
+	c := NewConfig()
+	c.Set("ns", "key", "value")
+
+	s, err := c.GetString("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", s)
+
+	s, err = c.GetString("other", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+}
+
+// recordingT counts failures instead of failing the test.
+type recordingT struct {
+	errors int
+}
+
+func (r *recordingT) Logf(string, ...interface{}) {}
+
+func (r *recordingT) Errorf(string, ...interface{}) { r.errors++ }
+
+func (r *recordingT) FailNow() {}
+
+func TestMocksAssertExpectations(t *testing.T) {
+	var m Mocks
+	m.Account.On("Get").Return(nil, nil)
+	m.Volumes.On("List").Return(nil, nil)
+
+	rt := &recordingT{}
+	assert.False(t, m.AssertExpectations(rt))
+	assert.Equal(t, 2, rt.errors)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// contextKey names the context used when --context isn't given.
+	contextKey = "context"
+	// contextsKey holds the settings for each context.
+	contextsKey = "contexts"
+	// defaultContext uses the top level settings only.
+	defaultContext = "default"
+)
+
+// Config creates the config commands hierarchy.
+func Config() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "config",
+			Short: "config commands",
+			Long:  "config is used to inspect doctl configuration",
+		},
+	}
+
+	// view only reads the config file, so it is built without CmdBuilder to
+	// avoid requiring an access token.
+	cmd.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:   "view",
+			Short: "show the effective configuration",
+			Long:  "show the configuration for the current context, or the one given with --context, merged over the top level settings",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunConfigView(cfgFile, viper.GetString(doctl.ArgContext), Writer), cmd)
+			},
+		},
+	})
+
+	return cmd
+}
+
+// RunConfigView writes the merged configuration for context as yaml. Access
+// tokens are masked.
+func RunConfigView(path, context string, out io.Writer) error {
+	ms, err := contextConfig(path, context)
+	if err != nil {
+		return err
+	}
+
+	for i := range ms {
+		if ms[i].Key == "access-token" {
+			ms[i].Value = maskToken(fmt.Sprint(ms[i].Value))
+		}
+	}
+
+	b, err := yaml.Marshal(ms)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(b)
+	return err
+}
+
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "********"
+	}
+
+	return token[:4] + "********"
+}
+
+// readConfig loads the config file at path into viper with the settings for
+// context merged over the top level ones.
+func readConfig(path, context string) error {
+	ms, err := contextConfig(path, context)
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(ms)
+	if err != nil {
+		return err
+	}
+
+	return viper.ReadConfig(bytes.NewReader(b))
+}
+
+// contextConfig returns the config file at path with the contexts section
+// replaced by the settings of context. If context is empty, the context
+// named in the file is used.
+func contextConfig(path, context string) (yaml.MapSlice, error) {
+	ms, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts yaml.MapSlice
+	base := yaml.MapSlice{}
+	for _, item := range ms {
+		switch item.Key {
+		case contextsKey:
+			var ok bool
+			contexts, ok = item.Value.(yaml.MapSlice)
+			if !ok {
+				return nil, fmt.Errorf("%s in %s is not a map", contextsKey, path)
+			}
+		case contextKey:
+			if context == "" {
+				context = fmt.Sprint(item.Value)
+			}
+		default:
+			base = append(base, item)
+		}
+	}
+
+	if context == "" || context == defaultContext {
+		return base, nil
+	}
+
+	for _, item := range contexts {
+		if fmt.Sprint(item.Key) != context {
+			continue
+		}
+
+		settings, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("context %q in %s is not a map", context, path)
+		}
+
+		merged := mergeConfig(base, settings)
+		return append(yaml.MapSlice{{Key: contextKey, Value: context}}, merged...), nil
+	}
+
+	return nil, fmt.Errorf("context %q is not defined in %s", context, path)
+}
+
+// mergeConfig returns base with the values in over replacing its own. Maps
+// are merged key by key.
+func mergeConfig(base, over yaml.MapSlice) yaml.MapSlice {
+	merged := append(yaml.MapSlice{}, base...)
+
+	for _, o := range over {
+		found := false
+		for i := range merged {
+			if merged[i].Key != o.Key {
+				continue
+			}
+
+			found = true
+			bm, bok := merged[i].Value.(yaml.MapSlice)
+			om, ook := o.Value.(yaml.MapSlice)
+			if bok && ook {
+				merged[i].Value = mergeConfig(bm, om)
+			} else {
+				merged[i].Value = o.Value
+			}
+		}
+
+		if !found {
+			merged = append(merged, o)
+		}
+	}
+
+	return merged
+}
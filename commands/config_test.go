@@ -0,0 +1,95 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContextConfig = `access-token: 0123456789abcdef
+context: staging
+output: text
+droplet:
+  create:
+    region: nyc1
+    size: 512mb
+contexts:
+  staging:
+    access-token: fedcba9876543210
+    droplet:
+      create:
+        region: sfo2
+  prod:
+    output: json
+`
+
+func writeTestConfig(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "doctl-config")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testContextConfig), 0600))
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestRunConfigView(t *testing.T) {
+	path, cleanup := writeTestConfig(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	err := RunConfigView(path, "", &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `context: staging
+access-token: fedc********
+output: text
+droplet:
+  create:
+    region: sfo2
+    size: 512mb
+`, buf.String())
+
+	buf.Reset()
+	err = RunConfigView(path, "prod", &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `context: prod
+access-token: 0123********
+output: json
+droplet:
+  create:
+    region: nyc1
+    size: 512mb
+`, buf.String())
+
+	buf.Reset()
+	err = RunConfigView(path, "default", &buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "region: nyc1")
+	assert.NotContains(t, buf.String(), "context")
+}
+
+func TestContextConfigUnknown(t *testing.T) {
+	path, cleanup := writeTestConfig(t)
+	defer cleanup()
+
+	_, err := contextConfig(path, "missing")
+	assert.Error(t, err)
+}
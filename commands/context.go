@@ -0,0 +1,55 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/doctl/do"
+)
+
+// cmdContext is the context commands run with. It is given to each
+// CmdConfig, and API requests are sent with it.
+var cmdContext = context.Background()
+
+// cmdContextObserver sends every API request with cmdContext, so requests
+// in flight are abandoned when it is cancelled.
+var cmdContextObserver = do.RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+	return req.WithContext(cmdContext), nil
+})
+
+// ctx returns the context the command runs with.
+func (c *CmdConfig) ctx() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+
+	return c.Context
+}
+
+// sleepContext pauses for d, or until ctx is done, in which case ctx's
+// error is returned.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
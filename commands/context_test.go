@@ -0,0 +1,52 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdContextObserver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	defer func(ctx context.Context) { cmdContext = ctx }(cmdContext)
+	client := &http.Client{Transport: do.NewObservedTransport(nil, cmdContextObserver)}
+
+	resp, err := client.Get(ts.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmdContext = ctx
+
+	_, err = client.Get(ts.URL)
+	assert.Error(t, err)
+}
+
+func TestSleepContext(t *testing.T) {
+	assert.NoError(t, sleepContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, sleepContext(ctx, time.Hour))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+)
+
+// backupsPriceRatio is the price of droplet backups relative to the droplet.
+const backupsPriceRatio = 0.2
+
+// costLine is one item of a cost estimate.
+type costLine struct {
+	Item     string  `json:"item"`
+	Quantity int     `json:"quantity"`
+	Hourly   float64 `json:"price_hourly"`
+	Monthly  float64 `json:"price_monthly"`
+}
+
+// Cost creates the cost command.
+func Cost() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "cost",
+			Short: "cost commands",
+			Long:  "cost is used to estimate the cost of resources before creating them",
+		},
+		DocCategories: []string{"cost"},
+		IsIndex:       true,
+	}
+
+	cmdCostEstimate := CmdBuilder(cmd, RunCostEstimate, "estimate", "estimate the cost of droplets", Writer,
+		displayerType(&costEstimate{}), docCategories("cost"))
+	AddStringFlag(cmdCostEstimate, doctl.ArgSizeSlug, "", "Droplet size", requiredOpt())
+	AddIntFlag(cmdCostEstimate, doctl.ArgCount, 1, "Number of droplets")
+	AddBoolFlag(cmdCostEstimate, doctl.ArgBackups, false, "Include backups")
+	AddBoolFlag(cmdCostEstimate, doctl.ArgMonitoring, false, "Include monitoring")
+
+	return cmd
+}
+
+// RunCostEstimate estimates the hourly and monthly cost of droplets from the
+// current size pricing.
+func RunCostEstimate(c *CmdConfig) error {
+	slug, err := c.Doit.GetString(c.NS, doctl.ArgSizeSlug)
+	if err != nil {
+		return err
+	}
+
+	count, err := c.Doit.GetInt(c.NS, doctl.ArgCount)
+	if err != nil {
+		return err
+	}
+	if count < 1 {
+		return fmt.Errorf("%s must be at least 1", doctl.ArgCount)
+	}
+
+	backups, err := c.Doit.GetBool(c.NS, doctl.ArgBackups)
+	if err != nil {
+		return err
+	}
+
+	monitoring, err := c.Doit.GetBool(c.NS, doctl.ArgMonitoring)
+	if err != nil {
+		return err
+	}
+
+	sizes, err := c.Sizes().List()
+	if err != nil {
+		return err
+	}
+
+	var hourly, monthly float64
+	found := false
+	for _, s := range sizes {
+		if s.Slug == slug {
+			hourly, monthly = s.PriceHourly, s.PriceMonthly
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown size %q", slug)
+	}
+
+	n := float64(count)
+	lines := []costLine{
+		{Item: "droplet " + slug, Quantity: count, Hourly: hourly * n, Monthly: monthly * n},
+	}
+	if backups {
+		lines = append(lines, costLine{
+			Item:     "backups",
+			Quantity: count,
+			Hourly:   hourly * n * backupsPriceRatio,
+			Monthly:  monthly * n * backupsPriceRatio,
+		})
+	}
+	if monitoring {
+		lines = append(lines, costLine{Item: "monitoring", Quantity: count})
+	}
+
+	total := costLine{Item: "total"}
+	for _, l := range lines {
+		total.Hourly += l.Hourly
+		total.Monthly += l.Monthly
+	}
+	lines = append(lines, total)
+
+	return c.Display(&costEstimate{lines: lines})
+}
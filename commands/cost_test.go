@@ -0,0 +1,76 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCostSizes = do.Sizes{
+	{Size: &godo.Size{Slug: "512mb", PriceHourly: 0.00744, PriceMonthly: 5}},
+	{Size: &godo.Size{Slug: "8gb", PriceHourly: 0.11905, PriceMonthly: 80}},
+}
+
+func TestCostCommand(t *testing.T) {
+	cmd := Cost()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "estimate")
+}
+
+func TestCostEstimate(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.sizes.On("List").Return(testCostSizes, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "8gb")
+		config.Doit.Set(config.NS, doctl.ArgCount, 3)
+		config.Doit.Set(config.NS, doctl.ArgBackups, true)
+		config.Doit.Set(config.NS, doctl.ArgMonitoring, true)
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunCostEstimate(config)
+		assert.NoError(t, err)
+
+		var rows []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			rows = append(rows, strings.Join(strings.Fields(line), " "))
+		}
+		assert.Equal(t, []string{
+			"droplet 8gb 3 0.35715 240.00",
+			"backups 3 0.07143 48.00",
+			"monitoring 3 0.00000 0.00",
+			"total 0.42858 288.00",
+		}, rows)
+	})
+}
+
+func TestCostEstimateUnknownSize(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.sizes.On("List").Return(testCostSizes, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "64gb")
+		config.Doit.Set(config.NS, doctl.ArgCount, 1)
+
+		err := RunCostEstimate(config)
+		assert.Error(t, err)
+	})
+}
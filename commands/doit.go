@@ -14,6 +14,7 @@ limitations under the License.
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -42,6 +44,9 @@ var Token string
 // Output holds the global output format.
 var Output string
 
+// Quiet toggles printing only identifiers.
+var Quiet bool
+
 // Verbose toggles verbose output.
 var Verbose bool
 
@@ -63,16 +68,36 @@ func init() {
 
 	DoitCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.doctlcfg)")
 	DoitCmd.PersistentFlags().StringVarP(&Token, "access-token", "t", "", "API V2 Access Token")
-	DoitCmd.PersistentFlags().StringVarP(&Output, "output", "o", "text", "output format [text|json]")
+	DoitCmd.PersistentFlags().StringVarP(&Output, "output", "o", "text", "output format [text|wide|json]")
 	DoitCmd.PersistentFlags().BoolVarP(&Verbose, "verbose", "v", false, "verbose output")
+	DoitCmd.PersistentFlags().BoolVarP(&Quiet, doctl.ArgQuiet, "q", false, "only print identifiers, one per line")
 	DoitCmd.PersistentFlags().BoolVarP(&Trace, "trace", "", false, "trace api access")
+	DoitCmd.PersistentFlags().Bool(doctl.ArgNoColor, false, "disable colored output")
+	DoitCmd.PersistentFlags().String(doctl.ArgProgress, "", "progress output for long running operations [text|json|none]")
+	DoitCmd.PersistentFlags().String(doctl.ArgAuditLog, "", "append a JSON record of each command to this file")
+	DoitCmd.PersistentFlags().String(doctl.ArgContext, "", "config context to use (default is the config file's context)")
 
 	viper.SetEnvPrefix("DIGITALOCEAN")
 	viper.BindEnv("access-token", "DIGITALOCEAN_ACCESS_TOKEN")
 	viper.BindPFlag("access-token", DoitCmd.PersistentFlags().Lookup("access-token"))
 	viper.BindPFlag("output", DoitCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag(doctl.ArgQuiet, DoitCmd.PersistentFlags().Lookup(doctl.ArgQuiet))
+	viper.BindPFlag(doctl.ArgNoColor, DoitCmd.PersistentFlags().Lookup(doctl.ArgNoColor))
+	viper.BindPFlag(doctl.ArgProgress, DoitCmd.PersistentFlags().Lookup(doctl.ArgProgress))
+	viper.BindPFlag(doctl.ArgAuditLog, DoitCmd.PersistentFlags().Lookup(doctl.ArgAuditLog))
+	viper.BindPFlag(doctl.ArgContext, DoitCmd.PersistentFlags().Lookup(doctl.ArgContext))
+	viper.BindEnv(doctl.ArgContext, "DIGITALOCEAN_CONTEXT")
 	viper.BindEnv("enable-beta", "DIGITALOCEAN_ENABLE_BETA")
 
+	if lc, ok := doctl.DoitConfig.(*doctl.LiveConfig); ok {
+		lc.AddRequestObserver(cmdContextObserver)
+		lc.AddRequestObserver(auditObserver)
+	}
+
+	AddPreRunHook(validateProgress)
+	AddPreRunHook(auditPreRun)
+	AddPostRunHook(auditPostRun)
+
 	addCommands()
 }
 
@@ -87,17 +112,33 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	if _, err := os.Stat(cfgFile); err == nil {
-		if err := viper.ReadInConfig(); err != nil {
+		if err := readConfig(cfgFile, viper.GetString(doctl.ArgContext)); err != nil {
 			log.Fatalln("reading initialization failed:", err)
 		}
 	}
 
 	viper.SetDefault("output", "text")
 
+	if viper.GetBool(doctl.ArgNoColor) || os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
 }
 
 // Execute executes the current command using DoitCmd.
 func Execute() {
+	handleInterrupts()
+
+	args := os.Args[1:]
+	aliases, err := readAliases(configPathFromArgs(args))
+	if err == nil {
+		args, err = expandAlias(args, aliases)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	DoitCmd.SetArgs(args)
+
 	if err := DoitCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
@@ -107,8 +148,19 @@ func Execute() {
 // AddCommands adds sub commands to the base command.
 func addCommands() {
 	DoitCmd.AddCommand(Account())
+	DoitCmd.AddCommand(Alias())
+	DoitCmd.AddCommand(Apply())
 	DoitCmd.AddCommand(Auth())
+	DoitCmd.AddCommand(Batch())
+	DoitCmd.AddCommand(Commands())
+	DoitCmd.AddCommand(Config())
 	DoitCmd.AddCommand(computeCmd())
+	DoitCmd.AddCommand(Diff())
+	DoitCmd.AddCommand(Export())
+	DoitCmd.AddCommand(Report())
+	DoitCmd.AddCommand(Resource())
+	DoitCmd.AddCommand(Search())
+	DoitCmd.AddCommand(Validate())
 	DoitCmd.AddCommand(Version())
 }
 
@@ -122,6 +174,7 @@ func computeCmd() *Command {
 	}
 
 	cmd.AddCommand(Actions())
+	cmd.AddCommand(Cost())
 	cmd.AddCommand(DropletAction())
 	cmd.AddCommand(Droplet())
 	cmd.AddCommand(Domain())
@@ -132,6 +185,7 @@ func computeCmd() *Command {
 	cmd.AddCommand(Plugin())
 	cmd.AddCommand(Region())
 	cmd.AddCommand(Size())
+	cmd.AddCommand(Snapshot())
 	cmd.AddCommand(SSHKeys())
 	cmd.AddCommand(Tags())
 	cmd.AddCommand(Volume())
@@ -173,8 +227,13 @@ func isBeta() bool {
 
 // AddStringFlag adds a string flag to a command.
 func AddStringFlag(cmd *Command, name, dflt, desc string, opts ...flagOpt) {
+	AddStringFlagP(cmd, name, "", dflt, desc, opts...)
+}
+
+// AddStringFlagP adds a string flag with a shorthand to a command.
+func AddStringFlagP(cmd *Command, name, shorthand, dflt, desc string, opts ...flagOpt) {
 	fn := flagName(cmd, name)
-	cmd.Flags().String(name, dflt, desc)
+	cmd.Flags().StringP(name, shorthand, dflt, desc)
 
 	for _, o := range opts {
 		o(cmd, name, fn)
@@ -207,8 +266,13 @@ func AddBoolFlag(cmd *Command, name string, def bool, desc string, opts ...flagO
 
 // AddStringSliceFlag adds a string slice flag to a command.
 func AddStringSliceFlag(cmd *Command, name string, def []string, desc string, opts ...flagOpt) {
+	AddStringSliceFlagP(cmd, name, "", def, desc, opts...)
+}
+
+// AddStringSliceFlagP adds a string slice flag with a shorthand to a command.
+func AddStringSliceFlagP(cmd *Command, name, shorthand string, def []string, desc string, opts ...flagOpt) {
 	fn := flagName(cmd, name)
-	cmd.Flags().StringSlice(name, def, desc)
+	cmd.Flags().StringSliceP(name, shorthand, def, desc)
 	viper.BindPFlag(fn, cmd.Flags().Lookup(name))
 
 	for _, o := range opts {
@@ -244,6 +308,11 @@ type CmdConfig struct {
 	Out  io.Writer
 	Args []string
 
+	// Context is cancelled when the command should stop. Commands check it
+	// while they wait on long running operations. A nil Context is never
+	// cancelled.
+	Context context.Context
+
 	// services
 	Keys              func() do.KeysService
 	Sizes             func() do.SizesService
@@ -269,11 +338,18 @@ func NewCmdConfig(ns string, dc doctl.Config, out io.Writer, args []string) (*Cm
 		return nil, err
 	}
 
+	return NewCmdConfigWithClient(ns, dc, out, args, godoClient), nil
+}
+
+// NewCmdConfigWithClient creates an instance of a CmdConfig whose services
+// use the supplied godo client.
+func NewCmdConfigWithClient(ns string, dc doctl.Config, out io.Writer, args []string, godoClient *godo.Client) *CmdConfig {
 	return &CmdConfig{
-		NS:   ns,
-		Doit: dc,
-		Out:  out,
-		Args: args,
+		NS:      ns,
+		Doit:    dc,
+		Out:     out,
+		Args:    args,
+		Context: cmdContext,
 
 		Keys:              func() do.KeysService { return do.NewKeysService(godoClient) },
 		Sizes:             func() do.SizesService { return do.NewSizesService(godoClient) },
@@ -284,13 +360,13 @@ func NewCmdConfig(ns string, dc doctl.Config, out io.Writer, args []string) (*Cm
 		FloatingIPActions: func() do.FloatingIPActionsService { return do.NewFloatingIPActionsService(godoClient) },
 		Droplets:          func() do.DropletsService { return do.NewDropletsService(godoClient) },
 		DropletActions:    func() do.DropletActionsService { return do.NewDropletActionsService(godoClient) },
-		Domains:           func() do.DomainsService { return do.NewDomainsService(godoClient) },
+		Domains:           func() do.DomainsService { return newJournaledDomainsService(do.NewDomainsService(godoClient)) },
 		Actions:           func() do.ActionsService { return do.NewActionsService(godoClient) },
 		Account:           func() do.AccountService { return do.NewAccountService(godoClient) },
 		Tags:              func() do.TagsService { return do.NewTagsService(godoClient) },
 		Volumes:           func() do.VolumesService { return do.NewVolumesService(godoClient) },
 		VolumeActions:     func() do.VolumeActionsService { return do.NewVolumeActionsService(godoClient) },
-	}, nil
+	}
 }
 
 // Display displayes the output from a command.
@@ -311,22 +387,24 @@ func CmdBuilder(parent *Command, cr CmdRunner, cliText, desc string, out io.Writ
 		Use:   cliText,
 		Short: desc,
 		Long:  desc,
-		Run: func(cmd *cobra.Command, args []string) {
-			c, err := NewCmdConfig(
-				cmdNS(cmd),
-				doctl.DoitConfig,
-				out,
-				args,
-			)
-			checkErr(err, cmd)
-
-			err = cr(c)
-			checkErr(err, cmd)
-		},
 	}
 
 	c := &Command{Command: cc}
 
+	cc.Run = func(cmd *cobra.Command, args []string) {
+		config, err := NewCmdConfig(
+			cmdNS(cmd),
+			doctl.DoitConfig,
+			out,
+			args,
+		)
+		checkErr(err, cmd)
+
+		err = c.runWithHooks(config, cr)
+		exitIfInterrupted()
+		checkErr(err, cmd)
+	}
+
 	if parent != nil {
 		parent.AddCommand(c)
 	}
@@ -0,0 +1,166 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	acmeChallengeLabel = "_acme-challenge"
+
+	// acmeChallengeTTL keeps challenge records from being cached for long,
+	// so a retried validation sees the new token.
+	acmeChallengeTTL = 30
+)
+
+func acmeChallenge() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "acme-challenge",
+			Short: "ACME DNS-01 challenge commands",
+			Long:  "acme-challenge manages the TXT records used for ACME DNS-01 validation, e.g. from certbot or lego hooks",
+		},
+	}
+
+	cmdSet := CmdBuilder(cmd, RunAcmeChallengeSet, "set <domain> <token>", "set a DNS-01 challenge token", Writer,
+		displayerType(&domainRecord{}), docCategories("domain"))
+	AddIntFlag(cmdSet, doctl.ArgRecordTTL, acmeChallengeTTL, "TTL in seconds of the challenge record")
+
+	CmdBuilder(cmd, RunAcmeChallengeClean, "clean <domain> [token]", "remove DNS-01 challenge tokens", Writer,
+		docCategories("domain"))
+
+	return cmd
+}
+
+// RunAcmeChallengeSet creates the _acme-challenge TXT record for a domain.
+// The domain may be a name within a zone, e.g. www.example.com or
+// *.example.com.
+func RunAcmeChallengeSet(c *CmdConfig) error {
+	if len(c.Args) != 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	ds := c.Domains()
+
+	zone, name, err := acmeChallengeRecordName(ds, c.Args[0])
+	if err != nil {
+		return err
+	}
+	token := c.Args[1]
+
+	ttl, err := c.Doit.GetInt(c.NS, doctl.ArgRecordTTL)
+	if err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		ttl = acmeChallengeTTL
+	}
+
+	records, err := ds.Records(zone)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.Type == "TXT" && r.Name == name && r.Data == token {
+			return c.Display(&domainRecord{domainRecords: do.DomainRecords{r}})
+		}
+	}
+
+	drcr := &godo.DomainRecordEditRequest{
+		Type: "TXT",
+		Name: name,
+		Data: token,
+	}
+
+	r, err := ds.CreateRecordWithTTL(zone, drcr, ttl)
+	if err != nil {
+		return err
+	}
+
+	return c.Display(&domainRecord{domainRecords: do.DomainRecords{*r}})
+}
+
+// RunAcmeChallengeClean removes _acme-challenge TXT records for a domain,
+// only the one holding token if it is given.
+func RunAcmeChallengeClean(c *CmdConfig) error {
+	if len(c.Args) < 1 || len(c.Args) > 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	ds := c.Domains()
+
+	zone, name, err := acmeChallengeRecordName(ds, c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	records, err := ds.Records(zone)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.Type != "TXT" || r.Name != name {
+			continue
+		}
+
+		if len(c.Args) == 2 && r.Data != c.Args[1] {
+			continue
+		}
+
+		err = ds.DeleteRecord(zone, r.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// acmeChallengeRecordName finds the zone hosting domain and the challenge
+// record name relative to it.
+func acmeChallengeRecordName(ds do.DomainsService, domain string) (string, string, error) {
+	domain = strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(domain), "."), "*.")
+
+	list, err := ds.List()
+	if err != nil {
+		return "", "", err
+	}
+
+	var zone string
+	for _, d := range list {
+		if (domain == d.Name || strings.HasSuffix(domain, "."+d.Name)) && len(d.Name) > len(zone) {
+			zone = d.Name
+		}
+	}
+
+	if zone == "" {
+		return "", "", fmt.Errorf("no zone hosted on DigitalOcean contains %s", domain)
+	}
+
+	name := acmeChallengeLabel
+	if domain != zone {
+		name += "." + strings.TrimSuffix(domain, "."+zone)
+	}
+
+	return zone, name, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAcmeDomains = do.Domains{
+	{Domain: &godo.Domain{Name: "example.com"}},
+	{Domain: &godo.Domain{Name: "dev.example.com"}},
+}
+
+func TestAcmeChallengeSet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testAcmeDomains, nil)
+		tm.domains.On("Records", "example.com").Return(do.DomainRecords{}, nil)
+		drcr := &godo.DomainRecordEditRequest{Type: "TXT", Name: "_acme-challenge.www", Data: "token"}
+		tm.domains.On("CreateRecordWithTTL", "example.com", drcr, 30).Return(&testRecord, nil)
+
+		config.Args = append(config.Args, "www.example.com", "token")
+		config.Doit.Set(config.NS, doctl.ArgRecordTTL, 30)
+
+		err := RunAcmeChallengeSet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAcmeChallengeSetExisting(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testAcmeDomains, nil)
+		existing := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 5, Type: "TXT", Name: "_acme-challenge", Data: "token"}},
+		}
+		tm.domains.On("Records", "dev.example.com").Return(existing, nil)
+
+		config.Args = append(config.Args, "*.dev.example.com", "token")
+
+		err := RunAcmeChallengeSet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAcmeChallengeClean(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testAcmeDomains, nil)
+		records := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "TXT", Name: "_acme-challenge", Data: "old"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "TXT", Name: "_acme-challenge", Data: "token"}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "TXT", Name: "@", Data: "token"}},
+		}
+		tm.domains.On("Records", "example.com").Return(records, nil)
+		tm.domains.On("DeleteRecord", "example.com", 2).Return(nil)
+
+		config.Args = append(config.Args, "example.com", "token")
+
+		err := RunAcmeChallengeClean(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAcmeChallengeUnknownZone(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testAcmeDomains, nil)
+
+		config.Args = append(config.Args, "example.org", "token")
+
+		err := RunAcmeChallengeSet(config)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// contextDomainsService builds the domains service for another config
+// context from its settings. The client is made the same way as the current
+// one, so its requests are cached, traced and audited. It is a variable so
+// tests can replace it.
+var contextDomainsService = func(c *CmdConfig, settings *viper.Viper) (do.DomainsService, error) {
+	lc, ok := c.Doit.(*doctl.LiveConfig)
+	if !ok {
+		return nil, fmt.Errorf("unable to build a client for another context")
+	}
+
+	client, err := lc.WithSettings(settings).GetGodoClient(Trace)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJournaledDomainsService(do.NewDomainsService(client)), nil
+}
+
+// contextSettings returns the settings of context in the config file at
+// path. The context must have an access token.
+func contextSettings(path, context string) (*viper.Viper, error) {
+	ms, err := contextConfig(path, context)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := yaml.Marshal(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+
+	if v.GetString("access-token") == "" {
+		return nil, fmt.Errorf("context %q has no access-token", context)
+	}
+
+	return v, nil
+}
+
+// RunDomainCopy recreates a domain and its records under the account of
+// another config context.
+func RunDomainCopy(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	domainName := c.Args[0]
+
+	toContext, err := c.Doit.GetString(c.NS, doctl.ArgToContext)
+	if err != nil {
+		return err
+	}
+	if toContext == "" {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	move, err := c.Doit.GetBool(c.NS, doctl.ArgMove)
+	if err != nil {
+		return err
+	}
+
+	settings, err := contextSettings(cfgFile, toContext)
+	if err != nil {
+		return err
+	}
+	if settings.GetString("access-token") == viper.GetString("access-token") {
+		return fmt.Errorf("context %q uses the current account", toContext)
+	}
+
+	src := c.Domains()
+	dst, err := contextDomainsService(c, settings)
+	if err != nil {
+		return err
+	}
+
+	records, err := src.Records(domainName)
+	if err != nil {
+		return err
+	}
+
+	// a domain can only exist in one account, so a move has to free the
+	// name before the copy is created.
+	if move {
+		if err := src.Delete(domainName); err != nil {
+			return err
+		}
+	}
+
+	copied, created, err := copyZone(dst, domainName, records)
+	if err != nil {
+		if move {
+			// the name has to be freed in the other account before the
+			// current one can take it back.
+			if created {
+				if derr := dst.Delete(domainName); derr != nil {
+					return fmt.Errorf("%v; removing the partial copy of %s from context %q also failed, it was not restored: %v",
+						err, domainName, toContext, derr)
+				}
+			}
+
+			if _, _, rerr := copyZone(src, domainName, records); rerr != nil {
+				return fmt.Errorf("%v; restoring %s in the current account also failed: %v", err, domainName, rerr)
+			}
+		}
+		return err
+	}
+
+	return c.Display(&domainRecord{domainRecords: copied})
+}
+
+// copyZone creates domainName with records through ds. The records
+// DigitalOcean adds to every new domain are skipped. created reports
+// whether the domain was made, even if creating its records then failed.
+func copyZone(ds do.DomainsService, domainName string, records do.DomainRecords) (copied do.DomainRecords, created bool, err error) {
+	if _, err := ds.Create(&godo.DomainCreateRequest{Name: domainName}); err != nil {
+		return nil, false, err
+	}
+
+	copied = do.DomainRecords{}
+	for _, r := range records {
+		if isDefaultRecord(r.DomainRecord) {
+			continue
+		}
+
+		nr, err := ds.CreateRecord(domainName, &godo.DomainRecordEditRequest{
+			Type:     r.Type,
+			Name:     r.Name,
+			Data:     r.Data,
+			Priority: r.Priority,
+			Port:     r.Port,
+			Weight:   r.Weight,
+		})
+		if err != nil {
+			return nil, true, err
+		}
+
+		copied = append(copied, *nr)
+	}
+
+	return copied, true, nil
+}
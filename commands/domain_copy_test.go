@@ -0,0 +1,211 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	domocks "github.com/digitalocean/doctl/do/mocks"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withCopyTarget(t *testing.T, fn func(dst *domocks.DomainsService)) {
+	dir, err := ioutil.TempDir("", "doctl-copy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`access-token: source-token
+contexts:
+  other:
+    access-token: other-token
+  empty:
+    output: json
+`), 0600))
+
+	savedCfg, savedFn := cfgFile, contextDomainsService
+	defer func() { cfgFile, contextDomainsService = savedCfg, savedFn }()
+	viper.Set("access-token", "source-token")
+	defer viper.Set("access-token", "")
+
+	dst := &domocks.DomainsService{}
+	cfgFile = path
+	contextDomainsService = func(c *CmdConfig, settings *viper.Viper) (do.DomainsService, error) {
+		assert.Equal(t, "other-token", settings.GetString("access-token"))
+		return dst, nil
+	}
+
+	fn(dst)
+}
+
+var testZone = do.DomainRecords{
+	{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@", Data: "1800"}},
+	{DomainRecord: &godo.DomainRecord{ID: 2, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+	{DomainRecord: &godo.DomainRecord{ID: 3, Type: "A", Name: "www", Data: "10.0.0.1"}},
+}
+
+func TestDomainCopy(t *testing.T) {
+	withCopyTarget(t, func(dst *domocks.DomainsService) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			req := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+			tm.domains.On("Records", "example.com").Return(testZone, nil)
+			dst.On("Create", &godo.DomainCreateRequest{Name: "example.com"}).Return(&testDomain, nil)
+			dst.On("CreateRecord", "example.com", req).Return(&testZone[2], nil)
+
+			config.Doit.Set(config.NS, doctl.ArgToContext, "other")
+			config.Args = append(config.Args, "example.com")
+
+			err := RunDomainCopy(config)
+			assert.NoError(t, err)
+			dst.AssertExpectations(t)
+			tm.domains.AssertNotCalled(t, "Delete", "example.com")
+		})
+	})
+}
+
+func TestDomainCopyMoveRestoresOnFailure(t *testing.T) {
+	withCopyTarget(t, func(dst *domocks.DomainsService) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			req := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+			tm.domains.On("Records", "example.com").Return(testZone, nil)
+			tm.domains.On("Delete", "example.com").Return(nil)
+			dst.On("Create", &godo.DomainCreateRequest{Name: "example.com"}).Return(nil, assert.AnError)
+			tm.domains.On("Create", &godo.DomainCreateRequest{Name: "example.com"}).Return(&testDomain, nil)
+			tm.domains.On("CreateRecord", "example.com", req).Return(&testZone[2], nil)
+
+			config.Doit.Set(config.NS, doctl.ArgToContext, "other")
+			config.Doit.Set(config.NS, doctl.ArgMove, true)
+			config.Args = append(config.Args, "example.com")
+
+			err := RunDomainCopy(config)
+			assert.Equal(t, assert.AnError, err)
+			tm.domains.AssertExpectations(t)
+		})
+	})
+}
+
+func TestDomainCopyMoveRemovesPartialCopy(t *testing.T) {
+	withCopyTarget(t, func(dst *domocks.DomainsService) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			mx := do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 4, Type: "MX", Name: "@", Data: "mail.example.com", Priority: 10}}
+			zone := append(do.DomainRecords{}, testZone...)
+			zone = append(zone, mx)
+
+			wwwReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+			mxReq := &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com", Priority: 10}
+			create := &godo.DomainCreateRequest{Name: "example.com"}
+
+			tm.domains.On("Records", "example.com").Return(zone, nil)
+			tm.domains.On("Delete", "example.com").Return(nil)
+			dst.On("Create", create).Return(&testDomain, nil)
+			dst.On("CreateRecord", "example.com", wwwReq).Return(&testZone[2], nil)
+			dst.On("CreateRecord", "example.com", mxReq).Return(nil, assert.AnError)
+			dst.On("Delete", "example.com").Return(nil)
+			tm.domains.On("Create", create).Return(&testDomain, nil)
+			tm.domains.On("CreateRecord", "example.com", wwwReq).Return(&testZone[2], nil)
+			tm.domains.On("CreateRecord", "example.com", mxReq).Return(&mx, nil)
+
+			config.Doit.Set(config.NS, doctl.ArgToContext, "other")
+			config.Doit.Set(config.NS, doctl.ArgMove, true)
+			config.Args = append(config.Args, "example.com")
+
+			err := RunDomainCopy(config)
+			assert.Equal(t, assert.AnError, err)
+			dst.AssertExpectations(t)
+			tm.domains.AssertExpectations(t)
+		})
+	})
+}
+
+func TestDomainCopyMovePartialCopyNotRemoved(t *testing.T) {
+	withCopyTarget(t, func(dst *domocks.DomainsService) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			wwwReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+
+			tm.domains.On("Records", "example.com").Return(testZone, nil)
+			tm.domains.On("Delete", "example.com").Return(nil)
+			dst.On("Create", &godo.DomainCreateRequest{Name: "example.com"}).Return(&testDomain, nil)
+			dst.On("CreateRecord", "example.com", wwwReq).Return(nil, assert.AnError)
+			dst.On("Delete", "example.com").Return(errors.New("boom"))
+
+			config.Doit.Set(config.NS, doctl.ArgToContext, "other")
+			config.Doit.Set(config.NS, doctl.ArgMove, true)
+			config.Args = append(config.Args, "example.com")
+
+			err := RunDomainCopy(config)
+			assert.Error(t, err)
+			tm.domains.AssertNotCalled(t, "Create", &godo.DomainCreateRequest{Name: "example.com"})
+		})
+	})
+}
+
+func TestDomainCopyBadContext(t *testing.T) {
+	withCopyTarget(t, func(dst *domocks.DomainsService) {
+		for _, context := range []string{"", "missing", "empty", "default"} {
+			withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+				config.Doit.Set(config.NS, doctl.ArgToContext, context)
+				config.Args = append(config.Args, "example.com")
+
+				err := RunDomainCopy(config)
+				assert.Error(t, err, context)
+			})
+		}
+	})
+}
+
+type authTransport struct {
+	auth []string
+}
+
+func (at *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	at.auth = append(at.auth, req.Header.Get("Authorization"))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"domain":{"name":"example.com"}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestContextDomainsService(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		settings := viper.New()
+		settings.Set("access-token", "other-token")
+
+		_, err := contextDomainsService(config, settings)
+		assert.Error(t, err)
+
+		at := &authTransport{}
+		config.Doit = doctl.NewLiveConfigWithHTTPClient(&http.Client{Transport: at})
+
+		ds, err := contextDomainsService(config, settings)
+		require.NoError(t, err)
+
+		d, err := ds.Get("example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com", d.Name)
+		assert.Equal(t, []string{"Bearer other-token"}, at.auth)
+	})
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+)
+
+// The DNS API keeps no history of a zone, so when the dns-journal config
+// setting is enabled doctl appends every record it creates, changes or
+// deletes to a local journal file.
+
+const (
+	journalOpCreate = "create"
+	journalOpUpdate = "update"
+	journalOpDelete = "delete"
+)
+
+var (
+	// journalRun identifies the entries written by one doctl invocation,
+	// so a change touching several records can be reviewed as a set.
+	journalRun = strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	journalNow = time.Now
+)
+
+// dnsJournalEntry is a single record mutation in the journal. Before is
+// unset for creates and After is unset for deletes. Undoes is the run
+// reverted by the change, if it was made by records undo.
+type dnsJournalEntry struct {
+	Time   time.Time          `json:"time"`
+	Run    string             `json:"run"`
+	Actor  string             `json:"actor"`
+	Domain string             `json:"domain"`
+	Op     string             `json:"op"`
+	Before *godo.DomainRecord `json:"before,omitempty"`
+	After  *godo.DomainRecord `json:"after,omitempty"`
+	Undoes string             `json:"undoes,omitempty"`
+}
+
+// dnsJournalPath returns the location of the journal, which can be set with
+// the dns-journal-file config setting.
+func dnsJournalPath() string {
+	if p := viper.GetString("dns-journal-file"); p != "" {
+		return p
+	}
+
+	return filepath.Join(homeDir(), ".doctl-dns-journal")
+}
+
+// journalActor names the local user and host making a change.
+func journalActor() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	if host, err := os.Hostname(); err == nil {
+		return name + "@" + host
+	}
+
+	return name
+}
+
+type dnsJournal struct {
+	path   string
+	actor  string
+	undoes string
+}
+
+func (j *dnsJournal) append(entries ...dnsJournalEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		e.Time = journalNow().UTC()
+		e.Run = journalRun
+		e.Actor = j.actor
+		e.Undoes = j.undoes
+
+		if err := enc.Encode(&e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	return f.Close()
+}
+
+// readDNSJournal returns the journal entries for domain, oldest first. A
+// missing journal has no entries.
+func readDNSJournal(path, domain string) ([]dnsJournalEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []dnsJournalEntry
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e dnsJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+
+		if e.Domain == domain {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// journaledDomainsService records the record mutations made through the
+// wrapped DomainsService.
+type journaledDomainsService struct {
+	do.DomainsService
+	journal *dnsJournal
+}
+
+var _ do.DomainsService = &journaledDomainsService{}
+
+// newJournaledDomainsService wraps ds with the DNS journal if it has been
+// enabled.
+func newJournaledDomainsService(ds do.DomainsService) do.DomainsService {
+	if !viper.GetBool("dns-journal") {
+		return ds
+	}
+
+	return &journaledDomainsService{
+		DomainsService: ds,
+		journal:        &dnsJournal{path: dnsJournalPath(), actor: journalActor()},
+	}
+}
+
+// record writes entries to the journal. The change has already been made
+// by then, so a journal failure is reported without failing the command.
+func (jds *journaledDomainsService) record(entries ...dnsJournalEntry) {
+	if err := jds.journal.append(entries...); err != nil {
+		warn(fmt.Sprintf("couldn't write DNS journal: %v", err))
+	}
+}
+
+// current fetches a record before it is changed so its old value can be
+// journaled.
+func (jds *journaledDomainsService) current(domain string, id int) *godo.DomainRecord {
+	r, err := jds.DomainsService.Record(domain, id)
+	if err != nil {
+		warn(fmt.Sprintf("couldn't read record %d for the DNS journal: %v", id, err))
+		return nil
+	}
+
+	return r.DomainRecord
+}
+
+func (jds *journaledDomainsService) Delete(domain string) error {
+	records, err := jds.DomainsService.Records(domain)
+	if err != nil {
+		warn(fmt.Sprintf("couldn't read records of %s for the DNS journal: %v", domain, err))
+	}
+
+	if err := jds.DomainsService.Delete(domain); err != nil {
+		return err
+	}
+
+	entries := []dnsJournalEntry{}
+	for _, r := range records {
+		entries = append(entries, dnsJournalEntry{Domain: domain, Op: journalOpDelete, Before: r.DomainRecord})
+	}
+	jds.record(entries...)
+
+	return nil
+}
+
+func (jds *journaledDomainsService) CreateRecord(domain string, drcr *godo.DomainRecordEditRequest) (*do.DomainRecord, error) {
+	r, err := jds.DomainsService.CreateRecord(domain, drcr)
+	if err != nil {
+		return nil, err
+	}
+
+	jds.record(dnsJournalEntry{Domain: domain, Op: journalOpCreate, After: r.DomainRecord})
+	return r, nil
+}
+
+func (jds *journaledDomainsService) CreateRecordWithTTL(domain string, drcr *godo.DomainRecordEditRequest, ttl int) (*do.DomainRecord, error) {
+	r, err := jds.DomainsService.CreateRecordWithTTL(domain, drcr, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	jds.record(dnsJournalEntry{Domain: domain, Op: journalOpCreate, After: r.DomainRecord})
+	return r, nil
+}
+
+func (jds *journaledDomainsService) EditRecord(domain string, id int, drcr *godo.DomainRecordEditRequest) (*do.DomainRecord, error) {
+	before := jds.current(domain, id)
+
+	r, err := jds.DomainsService.EditRecord(domain, id, drcr)
+	if err != nil {
+		return nil, err
+	}
+
+	jds.record(dnsJournalEntry{Domain: domain, Op: journalOpUpdate, Before: before, After: r.DomainRecord})
+	return r, nil
+}
+
+func (jds *journaledDomainsService) DeleteRecord(domain string, id int) error {
+	before := jds.current(domain, id)
+
+	if err := jds.DomainsService.DeleteRecord(domain, id); err != nil {
+		return err
+	}
+
+	jds.record(dnsJournalEntry{Domain: domain, Op: journalOpDelete, Before: before})
+	return nil
+}
+
+// RunRecordHistory lists the journaled changes to a domain's records,
+// newest first.
+func RunRecordHistory(ns, path string, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return doctl.NewMissingArgsErr(ns)
+	}
+
+	entries, err := readDNSJournal(path, args[0])
+	if err != nil {
+		return err
+	}
+
+	history := recordHistory(entries)
+	sort.Stable(sort.Reverse(recordHistoryByTime(history)))
+
+	d := &displayer{ns: ns, config: doctl.DoitConfig, item: &history, out: out}
+	return d.Display()
+}
+
+type recordHistoryByTime recordHistory
+
+func (h recordHistoryByTime) Len() int           { return len(h) }
+func (h recordHistoryByTime) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h recordHistoryByTime) Less(i, j int) bool { return h[i].Time.Before(h[j].Time) }
+
+// lastUndoableRun finds the newest run in entries that deleted or updated
+// records and hasn't been undone, along with those deletes and updates.
+func lastUndoableRun(entries []dnsJournalEntry) (string, []dnsJournalEntry) {
+	undone := map[string]bool{}
+	for _, e := range entries {
+		if e.Undoes != "" {
+			undone[e.Undoes] = true
+		}
+	}
+
+	run := ""
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Undoes == "" && !undone[e.Run] && (e.Op == journalOpDelete || e.Op == journalOpUpdate) {
+			run = e.Run
+			break
+		}
+	}
+	if run == "" {
+		return "", nil
+	}
+
+	changes := []dnsJournalEntry{}
+	for _, e := range entries {
+		if e.Run == run && (e.Op == journalOpDelete || e.Op == journalOpUpdate) {
+			changes = append(changes, e)
+		}
+	}
+
+	return run, changes
+}
+
+// isDefaultRecord reports whether r is one of the records DigitalOcean adds
+// to a new domain.
+func isDefaultRecord(r *godo.DomainRecord) bool {
+	switch r.Type {
+	case "SOA":
+		return true
+	case "NS":
+		return r.Name == "@" && isDONameserver(strings.TrimSuffix(r.Data, "."))
+	}
+
+	return false
+}
+
+// RunRecordUndo reverts the newest journaled run that deleted or updated
+// records of a domain. Deleted records are re-created, and updated records
+// are set back to their previous values. A deleted domain is re-created
+// first.
+func RunRecordUndo(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	domainName := c.Args[0]
+
+	entries, err := readDNSJournal(dnsJournalPath(), domainName)
+	if err != nil {
+		return err
+	}
+
+	run, changes := lastUndoableRun(entries)
+	if run == "" {
+		return fmt.Errorf("the DNS journal has no deleted or updated records of %s to undo", domainName)
+	}
+
+	ds := c.Domains()
+	if jds, ok := ds.(*journaledDomainsService); ok {
+		jds.journal.undoes = run
+	} else {
+		warn("dns-journal isn't enabled, so this undo won't be recorded in the journal")
+	}
+
+	recreated := false
+	if _, err := ds.Get(domainName); err != nil {
+		if !do.IsNotFound(err) {
+			return err
+		}
+
+		if _, err := ds.Create(&godo.DomainCreateRequest{Name: domainName}); err != nil {
+			return err
+		}
+		recreated = true
+	}
+
+	restored := do.DomainRecords{}
+
+	// revert newest first, so a record changed twice ends up with the value
+	// it had before the run.
+	for i := len(changes) - 1; i >= 0; i-- {
+		e := changes[i]
+		if e.Before == nil {
+			warn(fmt.Sprintf("the journal has no previous value for a %s of %s, skipping it", e.Op, domainName))
+			continue
+		}
+		if recreated && isDefaultRecord(e.Before) {
+			continue
+		}
+
+		req := &godo.DomainRecordEditRequest{
+			Type:     e.Before.Type,
+			Name:     e.Before.Name,
+			Data:     e.Before.Data,
+			Priority: e.Before.Priority,
+			Port:     e.Before.Port,
+			Weight:   e.Before.Weight,
+		}
+
+		var r *do.DomainRecord
+		if e.Op == journalOpDelete {
+			r, err = ds.CreateRecord(domainName, req)
+		} else {
+			r, err = ds.EditRecord(domainName, e.Before.ID, req)
+		}
+		if err != nil {
+			return err
+		}
+
+		restored = append(restored, *r)
+	}
+
+	return c.Display(&domainRecord{domainRecords: restored})
+}
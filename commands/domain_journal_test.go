@@ -0,0 +1,283 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withJournal(t *testing.T, fn func(path string, jds *journaledDomainsService, tm *tcMocks)) {
+	dir, err := ioutil.TempDir("", "doctl-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal")
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		jds := &journaledDomainsService{
+			DomainsService: &tm.domains,
+			journal:        &dnsJournal{path: path, actor: "sammy@host"},
+		}
+		fn(path, jds, tm)
+	})
+}
+
+func TestJournaledDomainsServiceRecordMutations(t *testing.T) {
+	withJournal(t, func(path string, jds *journaledDomainsService, tm *tcMocks) {
+		created := &do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}}
+		updated := &do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.2"}}
+
+		createReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+		editReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.2"}
+		tm.domains.On("CreateRecord", "example.com", createReq).Return(created, nil)
+		tm.domains.On("Record", "example.com", 1).Return(created, nil).Once()
+		tm.domains.On("EditRecord", "example.com", 1, editReq).Return(updated, nil)
+		tm.domains.On("Record", "example.com", 1).Return(updated, nil).Once()
+		tm.domains.On("DeleteRecord", "example.com", 1).Return(nil)
+
+		_, err := jds.CreateRecord("example.com", createReq)
+		require.NoError(t, err)
+		_, err = jds.EditRecord("example.com", 1, editReq)
+		require.NoError(t, err)
+		require.NoError(t, jds.DeleteRecord("example.com", 1))
+
+		entries, err := readDNSJournal(path, "example.com")
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, journalOpCreate, entries[0].Op)
+		assert.Nil(t, entries[0].Before)
+		assert.Equal(t, "10.0.0.1", entries[0].After.Data)
+
+		assert.Equal(t, journalOpUpdate, entries[1].Op)
+		assert.Equal(t, "10.0.0.1", entries[1].Before.Data)
+		assert.Equal(t, "10.0.0.2", entries[1].After.Data)
+
+		assert.Equal(t, journalOpDelete, entries[2].Op)
+		assert.Equal(t, "10.0.0.2", entries[2].Before.Data)
+		assert.Nil(t, entries[2].After)
+
+		for _, e := range entries {
+			assert.Equal(t, "sammy@host", e.Actor)
+			assert.Equal(t, journalRun, e.Run)
+		}
+
+		other, err := readDNSJournal(path, "example.org")
+		require.NoError(t, err)
+		assert.Empty(t, other)
+	})
+}
+
+func TestJournaledDomainsServiceCreateRecordWithTTL(t *testing.T) {
+	withJournal(t, func(path string, jds *journaledDomainsService, tm *tcMocks) {
+		created := &do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "TXT", Name: "_acme-challenge", Data: "token"}}
+		req := &godo.DomainRecordEditRequest{Type: "TXT", Name: "_acme-challenge", Data: "token"}
+		tm.domains.On("CreateRecordWithTTL", "example.com", req, 30).Return(created, nil)
+
+		_, err := jds.CreateRecordWithTTL("example.com", req, 30)
+		require.NoError(t, err)
+
+		entries, err := readDNSJournal(path, "example.com")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, journalOpCreate, entries[0].Op)
+		assert.Equal(t, "token", entries[0].After.Data)
+	})
+}
+
+func TestJournaledDomainsServiceDeleteDomain(t *testing.T) {
+	withJournal(t, func(path string, jds *journaledDomainsService, tm *tcMocks) {
+		records := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "@", Data: "10.0.0.1"}},
+		}
+		tm.domains.On("Records", "example.com").Return(records, nil)
+		tm.domains.On("Delete", "example.com").Return(nil)
+
+		require.NoError(t, jds.Delete("example.com"))
+
+		entries, err := readDNSJournal(path, "example.com")
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, 2, entries[1].Before.ID)
+	})
+}
+
+func TestJournaledDomainsServiceFailedMutation(t *testing.T) {
+	withJournal(t, func(path string, jds *journaledDomainsService, tm *tcMocks) {
+		tm.domains.On("Record", "example.com", 1).Return(&testRecord, nil)
+		tm.domains.On("DeleteRecord", "example.com", 1).Return(assert.AnError)
+
+		assert.Error(t, jds.DeleteRecord("example.com", 1))
+
+		entries, err := readDNSJournal(path, "example.com")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestRecordHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctl-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal")
+	j := &dnsJournal{path: path, actor: "sammy@host"}
+
+	defer func() { journalNow = time.Now }()
+	now := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	journalNow = func() time.Time { return now }
+	require.NoError(t, j.append(dnsJournalEntry{Domain: "example.com", Op: journalOpCreate,
+		After: &godo.DomainRecord{Type: "A", Name: "www", Data: "10.0.0.1"}}))
+
+	now = now.Add(time.Hour)
+	require.NoError(t, j.append(dnsJournalEntry{Domain: "example.com", Op: journalOpUpdate,
+		Before: &godo.DomainRecord{Type: "A", Name: "www", Data: "10.0.0.1"},
+		After:  &godo.DomainRecord{Type: "A", Name: "www", Data: "10.0.0.2"}}))
+
+	var buf bytes.Buffer
+	err = RunRecordHistory("domain.records.history", path, []string{"example.com"}, &buf)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"2016-05-01T13:00:00Z", "update", "A", "www", "10.0.0.1", "10.0.0.2", "sammy@host"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"2016-05-01T12:00:00Z", "create", "A", "www", "10.0.0.1", "sammy@host"}, strings.Fields(lines[2]))
+
+	assert.Error(t, RunRecordHistory("domain.records.history", path, nil, &buf))
+}
+
+func TestRecordHistoryNoJournal(t *testing.T) {
+	var buf bytes.Buffer
+	err := RunRecordHistory("domain.records.history", "/nonexistent/journal", []string{"example.com"}, &buf)
+	assert.NoError(t, err)
+}
+
+func TestLastUndoableRun(t *testing.T) {
+	a := &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}
+	entries := []dnsJournalEntry{
+		{Run: "r1", Op: journalOpDelete, Before: a},
+		{Run: "r2", Op: journalOpUpdate, Before: a, After: a},
+		{Run: "r2", Op: journalOpCreate, After: a},
+		{Run: "r3", Op: journalOpCreate, After: a},
+	}
+
+	run, changes := lastUndoableRun(entries)
+	assert.Equal(t, "r2", run)
+	assert.Len(t, changes, 1)
+
+	entries = append(entries, dnsJournalEntry{Run: "r4", Op: journalOpUpdate, Before: a, After: a, Undoes: "r2"})
+	run, changes = lastUndoableRun(entries)
+	assert.Equal(t, "r1", run)
+	assert.Len(t, changes, 1)
+
+	run, _ = lastUndoableRun(entries[2:])
+	assert.Equal(t, "", run)
+}
+
+func withJournalFile(t *testing.T, entries []dnsJournalEntry, fn func()) {
+	dir, err := ioutil.TempDir("", "doctl-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal")
+	for _, e := range entries {
+		j := &dnsJournal{path: path, actor: "sammy@host"}
+		saved := journalRun
+		journalRun = e.Run
+		err := j.append(e)
+		journalRun = saved
+		require.NoError(t, err)
+	}
+
+	viper.Set("dns-journal-file", path)
+	defer viper.Set("dns-journal-file", "")
+
+	fn()
+}
+
+func TestRecordUndo(t *testing.T) {
+	www := &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}
+	mx := &godo.DomainRecord{ID: 2, Type: "MX", Name: "@", Data: "mail.example.com", Priority: 10}
+	entries := []dnsJournalEntry{
+		{Run: "r1", Domain: "example.com", Op: journalOpUpdate, Before: www, After: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.9"}},
+		{Run: "r2", Domain: "example.com", Op: journalOpDelete, Before: www},
+		{Run: "r2", Domain: "example.com", Op: journalOpDelete, Before: mx},
+		{Run: "r3", Domain: "example.org", Op: journalOpDelete, Before: www},
+	}
+
+	withJournalFile(t, entries, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			mxReq := &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com", Priority: 10}
+			wwwReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+			tm.domains.On("Get", "example.com").Return(&testDomain, nil)
+			tm.domains.On("CreateRecord", "example.com", mxReq).Return(&do.DomainRecord{DomainRecord: mx}, nil)
+			tm.domains.On("CreateRecord", "example.com", wwwReq).Return(&do.DomainRecord{DomainRecord: www}, nil)
+
+			config.Args = append(config.Args, "example.com")
+
+			err := RunRecordUndo(config)
+			assert.NoError(t, err)
+			tm.domains.AssertNumberOfCalls(t, "CreateRecord", 2)
+		})
+	})
+}
+
+func TestRecordUndoRecreatesDomain(t *testing.T) {
+	ns := &godo.DomainRecord{ID: 1, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}
+	www := &godo.DomainRecord{ID: 2, Type: "A", Name: "www", Data: "10.0.0.1"}
+	entries := []dnsJournalEntry{
+		{Run: "r1", Domain: "example.com", Op: journalOpDelete, Before: ns},
+		{Run: "r1", Domain: "example.com", Op: journalOpDelete, Before: www},
+	}
+
+	withJournalFile(t, entries, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			notFound := &do.NotFoundError{ErrorResponse: &godo.ErrorResponse{}}
+			wwwReq := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.1"}
+			tm.domains.On("Get", "example.com").Return(nil, notFound)
+			tm.domains.On("Create", &godo.DomainCreateRequest{Name: "example.com"}).Return(&testDomain, nil)
+			tm.domains.On("CreateRecord", "example.com", wwwReq).Return(&do.DomainRecord{DomainRecord: www}, nil)
+
+			config.Args = append(config.Args, "example.com")
+
+			err := RunRecordUndo(config)
+			assert.NoError(t, err)
+			tm.domains.AssertNumberOfCalls(t, "CreateRecord", 1)
+		})
+	})
+}
+
+func TestRecordUndoNothingToUndo(t *testing.T) {
+	withJournalFile(t, nil, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			config.Args = append(config.Args, "example.com")
+
+			err := RunRecordUndo(config)
+			assert.Error(t, err)
+		})
+	})
+}
@@ -14,9 +14,16 @@ limitations under the License.
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
@@ -24,6 +31,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	// doNameservers are the nameservers a domain must delegate to for
+	// DigitalOcean DNS to serve it.
+	doNameservers = []string{"ns1.digitalocean.com", "ns2.digitalocean.com", "ns3.digitalocean.com"}
+
+	lookupNSFunc = net.LookupNS
+
+	queryRecordFunc = queryRecord
+
+	verifyInterval           = 5 * time.Second
+	verifyOut      io.Writer = os.Stderr
+
+	// ttlExpirySleep waits for cached copies of a record to expire.
+	ttlExpirySleep = sleepContext
+)
+
 // Domain creates the domain commands heirarchy.
 func Domain() *Command {
 	cmd := &Command{
@@ -38,16 +61,28 @@ func Domain() *Command {
 
 	cmdDomainCreate := CmdBuilder(cmd, RunDomainCreate, "create <domain>", "create domain", Writer,
 		aliasOpt("c"), displayerType(&domain{}), docCategories("domain"))
-	AddStringFlag(cmdDomainCreate, doctl.ArgIPAddress, "", "IP address", requiredOpt())
+	AddStringFlag(cmdDomainCreate, doctl.ArgIPAddress, "", "IP address for the apex A record, omit for an empty zone")
+	AddBoolFlag(cmdDomainCreate, doctl.ArgCreateWWW, false, "Create a www CNAME record pointing at the apex")
+	AddStringFlag(cmdDomainCreate, doctl.ArgFromFile, "", "File with one domain and optional IP address per line")
 
 	CmdBuilder(cmd, RunDomainList, "list", "list domains", Writer,
 		aliasOpt("ls"), displayerType(&domain{}), docCategories("domain"))
 
 	CmdBuilder(cmd, RunDomainGet, "get <domain>", "get domain", Writer,
-		aliasOpt("g"), displayerType(&domain{}), docCategories("domain"))
+		aliasOpt("g"), displayerType(&domainZone{}), docCategories("domain"))
 
 	CmdBuilder(cmd, RunDomainDelete, "delete <domain>", "delete droplet", Writer, aliasOpt("g"))
 
+	cmdDomainCopy := CmdBuilder(cmd, RunDomainCopy, "copy <domain>", "copy a domain and its records to the account of another config context", Writer,
+		displayerType(&domainRecord{}), docCategories("domain"))
+	AddStringFlag(cmdDomainCopy, doctl.ArgToContext, "", "Config context to copy the domain to", requiredOpt())
+	AddBoolFlag(cmdDomainCopy, doctl.ArgMove, false, "Delete the domain from the current account before creating it in the other")
+
+	CmdBuilder(cmd, RunDomainVerify, "verify <domain>", "verify domain delegates to DigitalOcean nameservers", Writer,
+		aliasOpt("v"), displayerType(&nameserver{}), docCategories("domain"))
+
+	cmd.AddCommand(acmeChallenge())
+
 	cmdRecord := &Command{
 		Command: &cobra.Command{
 			Use:   "records",
@@ -69,25 +104,75 @@ func Domain() *Command {
 	AddIntFlag(cmdRecordCreate, doctl.ArgRecordPriority, 0, "Record priority")
 	AddIntFlag(cmdRecordCreate, doctl.ArgRecordPort, 0, "Record port")
 	AddIntFlag(cmdRecordCreate, doctl.ArgRecordWeight, 0, "Record weight")
+	AddBoolFlag(cmdRecordCreate, doctl.ArgRecordVerify, false, "Wait until the record is visible on DigitalOcean's nameservers")
+	AddIntFlag(cmdRecordCreate, doctl.ArgRecordVerifyTimeout, 120, "Seconds to wait for the record to become visible")
+	AddStringSliceFlag(cmdRecordCreate, doctl.ArgRecordResolvers, []string{}, "Additional resolvers to verify against, e.g. 8.8.8.8")
+
+	CmdBuilder(cmdRecord, RunRecordGet, "get <domain> <record id>", "get record", Writer,
+		aliasOpt("g"), displayerType(&domainRecord{}), docCategories("domain"))
+
+	cmdRecordImport := CmdBuilder(cmdRecord, RunRecordImport, "import <domain>", "import records from a route53 or cloudflare export", Writer,
+		displayerType(&recordImport{}), docCategories("domain"))
+	AddStringFlag(cmdRecordImport, doctl.ArgProvider, "", "Export format: route53 or cloudflare", requiredOpt())
+	AddStringFlagP(cmdRecordImport, doctl.ArgFile, "f", "", "Export file", requiredOpt())
 
 	CmdBuilder(cmdRecord, RunRecordDelete, "delete <domain> <record id...>", "delete record", Writer,
 		aliasOpt("d"), docCategories("domain"))
 
+	// history only reads the local journal, so it is built without
+	// CmdBuilder to avoid requiring an access token.
+	cmdRecord.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:   "history <domain>",
+			Short: "list journaled record changes",
+			Long:  "list the record changes doctl has made to a domain, newest first; requires dns-journal: true in the config file",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunRecordHistory(cmdNS(cmd), dnsJournalPath(), args, Writer), cmd)
+			},
+		},
+	})
+
+	CmdBuilder(cmdRecord, RunRecordUndo, "undo <domain>", "re-create the records deleted or overwritten by the last journaled change", Writer,
+		displayerType(&domainRecord{}), docCategories("domain"))
+
+	cmdRecordSwitch := CmdBuilder(cmdRecord, RunRecordSwitch, "switch <domain>", "lower the TTL of a name's A or AAAA records, wait for it to take effect, then point them at a new address", Writer,
+		displayerType(&domainRecord{}), docCategories("domain"))
+	AddStringFlag(cmdRecordSwitch, doctl.ArgRecordName, "", "Record name", requiredOpt())
+	AddStringFlag(cmdRecordSwitch, doctl.ArgFromIP, "", "Address the records point at now", requiredOpt())
+	AddStringFlag(cmdRecordSwitch, doctl.ArgToIP, "", "Address to point the records at", requiredOpt())
+	AddIntFlag(cmdRecordSwitch, doctl.ArgRecordTTL, 30, "TTL in seconds to lower the records to before switching, 0 to leave it alone")
+	AddBoolFlag(cmdRecordSwitch, doctl.ArgRestoreTTL, false, "Put the original TTL back once the records are switched")
+	AddBoolFlag(cmdRecordSwitch, doctl.ArgRecordVerify, true, "Wait until the new address is visible on DigitalOcean's nameservers")
+	AddIntFlag(cmdRecordSwitch, doctl.ArgRecordVerifyTimeout, 120, "Seconds to wait for the new address to become visible")
+	AddStringSliceFlag(cmdRecordSwitch, doctl.ArgRecordResolvers, []string{}, "Additional resolvers to verify against, e.g. 8.8.8.8")
+
 	cmdRecordUpdate := CmdBuilder(cmdRecord, RunRecordUpdate, "update <domain>", "update record", Writer,
 		aliasOpt("u"), displayerType(&domainRecord{}), docCategories("domain"))
-	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordID, 0, "Record ID")
+	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordID, 0, "Record ID, looked up by name and type if omitted")
 	AddStringFlag(cmdRecordUpdate, doctl.ArgRecordType, "", "Record type")
 	AddStringFlag(cmdRecordUpdate, doctl.ArgRecordName, "", "Record name")
 	AddStringFlag(cmdRecordUpdate, doctl.ArgRecordData, "", "Record data")
 	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordPriority, 0, "Record priority")
 	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordPort, 0, "Record port")
 	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordWeight, 0, "Record weight")
+	AddBoolFlag(cmdRecordUpdate, doctl.ArgRecordVerify, false, "Wait until the record is visible on DigitalOcean's nameservers")
+	AddIntFlag(cmdRecordUpdate, doctl.ArgRecordVerifyTimeout, 120, "Seconds to wait for the record to become visible")
+	AddStringSliceFlag(cmdRecordUpdate, doctl.ArgRecordResolvers, []string{}, "Additional resolvers to verify against, e.g. 8.8.8.8")
 
 	return cmd
 }
 
 // RunDomainCreate runs domain create.
 func RunDomainCreate(c *CmdConfig) error {
+	fromFile, err := c.Doit.GetString(c.NS, doctl.ArgFromFile)
+	if err != nil {
+		return err
+	}
+
+	if fromFile != "" {
+		return runDomainCreateFromFile(c, fromFile)
+	}
+
 	if len(c.Args) != 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
@@ -95,7 +180,7 @@ func RunDomainCreate(c *CmdConfig) error {
 
 	ds := c.Domains()
 
-	ipAddress, err := c.Doit.GetString(c.NS, "ip-address")
+	ipAddress, err := c.Doit.GetString(c.NS, doctl.ArgIPAddress)
 	if err != nil {
 		return err
 	}
@@ -105,14 +190,86 @@ func RunDomainCreate(c *CmdConfig) error {
 		IPAddress: ipAddress,
 	}
 
+	createWWW, err := c.Doit.GetBool(c.NS, doctl.ArgCreateWWW)
+	if err != nil {
+		return err
+	}
+
 	d, err := ds.Create(req)
 	if err != nil {
 		return err
 	}
 
+	if createWWW {
+		drcr := &godo.DomainRecordEditRequest{
+			Type: "CNAME",
+			Name: "www",
+			Data: domainName + ".",
+		}
+
+		_, err = ds.CreateRecord(domainName, drcr)
+		if err != nil {
+			return err
+		}
+	}
+
 	return c.Display(&domain{domains: do.Domains{*d}})
 }
 
+// runDomainCreateFromFile creates every domain listed in path, reporting
+// the outcome for each one. Lines hold a domain name and an optional IP
+// address that overrides --ip-address; blank lines and # comments are
+// skipped.
+func runDomainCreateFromFile(c *CmdConfig, path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	ipAddress, err := c.Doit.GetString(c.NS, doctl.ArgIPAddress)
+	if err != nil {
+		return err
+	}
+
+	ds := c.Domains()
+
+	var results []domainCreateResult
+	failed := 0
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		req := &godo.DomainCreateRequest{
+			Name:      fields[0],
+			IPAddress: ipAddress,
+		}
+		if len(fields) > 1 {
+			req.IPAddress = fields[1]
+		}
+
+		result := domainCreateResult{Domain: req.Name, Status: "created"}
+		if _, err := ds.Create(req); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	err = c.Display(&domainCreateResults{results: results})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to create %d of %d domains", failed, len(results))
+	}
+
+	return nil
+}
+
 // RunDomainList runs domain create.
 func RunDomainList(c *CmdConfig) error {
 
@@ -145,7 +302,7 @@ func RunDomainGet(c *CmdConfig) error {
 		return err
 	}
 
-	item := &domain{domains: do.Domains{*d}}
+	item := &domainZone{domains: do.Domains{*d}}
 	return c.Display(item)
 }
 
@@ -166,6 +323,63 @@ func RunDomainDelete(c *CmdConfig) error {
 	return err
 }
 
+// RunDomainVerify looks up a domain's live nameservers and checks that they
+// are DigitalOcean's.
+func RunDomainVerify(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	name := c.Args[0]
+
+	records, err := lookupNSFunc(name)
+	if err != nil {
+		return fmt.Errorf("unable to look up nameservers for %s: %v", name, err)
+	}
+
+	live := map[string]bool{}
+	var statuses []nameserverStatus
+	delegated := true
+	for _, r := range records {
+		host := strings.ToLower(strings.TrimSuffix(r.Host, "."))
+		live[host] = true
+
+		status := "ok"
+		if !isDONameserver(host) {
+			status = "foreign"
+			delegated = false
+		}
+		statuses = append(statuses, nameserverStatus{Nameserver: host, Status: status})
+	}
+
+	for _, ns := range doNameservers {
+		if !live[ns] {
+			statuses = append(statuses, nameserverStatus{Nameserver: ns, Status: "missing"})
+			delegated = false
+		}
+	}
+
+	err = c.Display(&nameserver{nameservers: statuses})
+	if err != nil {
+		return err
+	}
+
+	if !delegated {
+		return fmt.Errorf("%s does not delegate to DigitalOcean nameservers %s", name, strings.Join(doNameservers, ", "))
+	}
+
+	return nil
+}
+
+func isDONameserver(host string) bool {
+	for _, ns := range doNameservers {
+		if host == ns {
+			return true
+		}
+	}
+
+	return false
+}
+
 // RunRecordList list records for a domain.
 func RunRecordList(c *CmdConfig) error {
 	if len(c.Args) != 1 {
@@ -246,11 +460,39 @@ func RunRecordCreate(c *CmdConfig) error {
 		return err
 	}
 
+	err = verifyRecordIfRequested(c, name, r)
+	if err != nil {
+		return err
+	}
+
 	item := &domainRecord{domainRecords: do.DomainRecords{*r}}
 	return c.Display(item)
 
 }
 
+// RunRecordGet retrieves a single domain record.
+func RunRecordGet(c *CmdConfig) error {
+	if len(c.Args) != 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	domainName := c.Args[0]
+	id, err := strconv.Atoi(c.Args[1])
+	if err != nil {
+		return fmt.Errorf("invalid record id %q", c.Args[1])
+	}
+
+	ds := c.Domains()
+
+	r, err := ds.Record(domainName, id)
+	if err != nil {
+		return err
+	}
+
+	item := &domainRecord{domainRecords: do.DomainRecords{*r}}
+	return c.Display(item)
+}
+
 // RunRecordDelete deletes a domain record.
 func RunRecordDelete(c *CmdConfig) error {
 	if len(c.Args) < 2 {
@@ -323,6 +565,13 @@ func RunRecordUpdate(c *CmdConfig) error {
 		return err
 	}
 
+	if recordID == 0 {
+		recordID, err = findRecordID(ds, domainName, rName, rType)
+		if err != nil {
+			return err
+		}
+	}
+
 	drcr := &godo.DomainRecordEditRequest{
 		Type:     rType,
 		Name:     rName,
@@ -337,6 +586,325 @@ func RunRecordUpdate(c *CmdConfig) error {
 		return err
 	}
 
+	err = verifyRecordIfRequested(c, domainName, r)
+	if err != nil {
+		return err
+	}
+
 	item := &domainRecord{domainRecords: do.DomainRecords{*r}}
 	return c.Display(item)
 }
+
+// RunRecordSwitch moves the A or AAAA records of a name from one address
+// to another, the DNS step of a blue/green cutover. The records' TTL is
+// lowered first and the old TTL is left to expire, so resolvers pick up the
+// new address quickly once it is switched. Records already at the new
+// address are left alone, so a switch can be repeated.
+func RunRecordSwitch(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	domainName := c.Args[0]
+
+	name, err := c.Doit.GetString(c.NS, doctl.ArgRecordName)
+	if err != nil {
+		return err
+	}
+
+	fromIP, err := c.Doit.GetString(c.NS, doctl.ArgFromIP)
+	if err != nil {
+		return err
+	}
+
+	toIP, err := c.Doit.GetString(c.NS, doctl.ArgToIP)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := c.Doit.GetInt(c.NS, doctl.ArgRecordTTL)
+	if err != nil {
+		return err
+	}
+
+	restoreTTL, err := c.Doit.GetBool(c.NS, doctl.ArgRestoreTTL)
+	if err != nil {
+		return err
+	}
+
+	from, to := net.ParseIP(fromIP), net.ParseIP(toIP)
+	if from == nil || to == nil {
+		return fmt.Errorf("%s and %s must be IP addresses", doctl.ArgFromIP, doctl.ArgToIP)
+	}
+	if (from.To4() == nil) != (to.To4() == nil) {
+		return fmt.Errorf("%s and %s must both be IPv4 or both be IPv6", doctl.ArgFromIP, doctl.ArgToIP)
+	}
+	if ttl < 0 {
+		return fmt.Errorf("%s must not be negative", doctl.ArgRecordTTL)
+	}
+
+	rType := "A"
+	if to.To4() == nil {
+		rType = "AAAA"
+	}
+
+	ds := c.Domains()
+
+	list, err := ds.Records(domainName)
+	if err != nil {
+		return err
+	}
+
+	switched := do.DomainRecords{}
+	var pending do.DomainRecords
+	for _, r := range list {
+		if r.Name != name || r.Type != rType {
+			continue
+		}
+
+		ip := net.ParseIP(r.Data)
+		switch {
+		case ip.Equal(to):
+			switched = append(switched, r)
+		case ip.Equal(from):
+			pending = append(pending, r)
+		}
+	}
+
+	if len(switched) == 0 && len(pending) == 0 {
+		return fmt.Errorf("no %s record named %q in %s points at %s", rType, name, domainName, fromIP)
+	}
+
+	// lower the TTL of the records to be switched, then wait out the
+	// longest old TTL so no resolver still caches the old address for long.
+	oldTTLs := map[int]int{}
+	wait := 0
+	if ttl > 0 {
+		for _, r := range pending {
+			old, err := ds.RecordTTL(domainName, r.ID)
+			if err != nil {
+				return err
+			}
+			if old <= ttl {
+				continue
+			}
+
+			if err := ds.EditRecordTTL(domainName, r.ID, ttl); err != nil {
+				return err
+			}
+			oldTTLs[r.ID] = old
+			if old > wait {
+				wait = old
+			}
+		}
+	}
+
+	if wait > 0 {
+		fmt.Fprintf(verifyOut, "lowered the TTL of %s.%s to %ds, waiting %ds for the old TTL to expire\n",
+			name, domainName, ttl, wait)
+		if err := ttlExpirySleep(c.ctx(), time.Duration(wait)*time.Second); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range pending {
+		nr, err := ds.EditRecord(domainName, r.ID, &godo.DomainRecordEditRequest{
+			Type: r.Type,
+			Name: r.Name,
+			Data: toIP,
+		})
+		if err != nil {
+			return err
+		}
+		switched = append(switched, *nr)
+	}
+
+	// the records are switched whether or not verification succeeds, so
+	// their TTL is put back either way.
+	verr := verifyRecordIfRequested(c, domainName, &switched[0])
+
+	if restoreTTL {
+		for _, r := range pending {
+			old, ok := oldTTLs[r.ID]
+			if !ok {
+				continue
+			}
+			if err := ds.EditRecordTTL(domainName, r.ID, old); err != nil {
+				return err
+			}
+		}
+	}
+
+	if verr != nil {
+		return verr
+	}
+
+	return c.Display(&domainRecord{domainRecords: switched})
+}
+
+// findRecordID locates the single record in a domain with the given name
+// and type.
+func findRecordID(ds do.DomainsService, domainName, name, rType string) (int, error) {
+	if name == "" || rType == "" {
+		return 0, errors.New("record id or record name and type are required")
+	}
+
+	list, err := ds.Records(domainName)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int
+	for _, r := range list {
+		if r.Name == name && strings.EqualFold(r.Type, rType) {
+			ids = append(ids, r.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no %s record named %q in %s", rType, name, domainName)
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, fmt.Errorf("%s record name %q in %s is ambiguous, matches records %s",
+			rType, name, domainName, joinInts(ids))
+	}
+}
+
+func verifyRecordIfRequested(c *CmdConfig, domainName string, r *do.DomainRecord) error {
+	verify, err := c.Doit.GetBool(c.NS, doctl.ArgRecordVerify)
+	if err != nil || !verify {
+		return err
+	}
+
+	timeout, err := c.Doit.GetInt(c.NS, doctl.ArgRecordVerifyTimeout)
+	if err != nil {
+		return err
+	}
+
+	resolvers, err := c.Doit.GetStringSlice(c.NS, doctl.ArgRecordResolvers)
+	if err != nil {
+		return err
+	}
+
+	servers := append([]string{}, doNameservers...)
+	servers = append(servers, resolvers...)
+
+	return verifyRecord(c.ctx(), domainName, r, servers, time.Duration(timeout)*time.Second)
+}
+
+// verifyRecord polls each server until it answers with the record's data,
+// the timeout expires or ctx is done.
+func verifyRecord(ctx context.Context, domainName string, r *do.DomainRecord, servers []string, timeout time.Duration) error {
+	fqdn := domainName
+	switch {
+	case strings.HasSuffix(r.Name, "."):
+		fqdn = strings.TrimSuffix(r.Name, ".")
+	case r.Name != "" && r.Name != "@":
+		fqdn = r.Name + "." + domainName
+	}
+	want := normalizeRecordData(r.Data)
+
+	deadline := time.Now().Add(timeout)
+	for _, server := range servers {
+		fmt.Fprintf(verifyOut, "waiting for %s %s on %s", r.Type, fqdn, server)
+		for {
+			answers, err := queryRecordFunc(server, fqdn, r.Type)
+			if err == nil && containsRecordData(answers, want) {
+				fmt.Fprintln(verifyOut, " visible")
+				break
+			}
+
+			if !time.Now().Add(verifyInterval).Before(deadline) {
+				fmt.Fprintln(verifyOut, " timed out")
+				return fmt.Errorf("%s %s was not visible on %s within %s", r.Type, fqdn, server, timeout)
+			}
+
+			fmt.Fprint(verifyOut, ".")
+			if err := sleepContext(ctx, verifyInterval); err != nil {
+				fmt.Fprintln(verifyOut, " cancelled")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsRecordData(answers []string, want string) bool {
+	for _, a := range answers {
+		if normalizeRecordData(a) == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeRecordData(s string) string {
+	return strings.ToLower(strings.TrimSuffix(s, "."))
+}
+
+// queryRecord asks server directly for the rtype records of fqdn.
+func queryRecord(server, fqdn, rtype string) ([]string, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			d := net.Dialer{}
+			return d.DialContext(ctx, network, server)
+		},
+	}
+	ctx := context.Background()
+
+	var out []string
+	switch strings.ToUpper(rtype) {
+	case "A", "AAAA":
+		addrs, err := resolver.LookupIPAddr(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range addrs {
+			out = append(out, a.IP.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cname)
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			out = append(out, mx.Host)
+		}
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			out = append(out, ns.Host)
+		}
+	case "TXT":
+		return resolver.LookupTXT(ctx, fqdn)
+	case "SRV":
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", fqdn)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			out = append(out, srv.Target)
+		}
+	default:
+		return nil, fmt.Errorf("verifying %s records is not supported", rtype)
+	}
+
+	return out, nil
+}
@@ -14,7 +14,16 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
@@ -34,7 +43,7 @@ var (
 func TestDomainsCommand(t *testing.T) {
 	cmd := Domain()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "create", "list", "get", "delete", "records")
+	assertCommandNames(t, cmd, "acme-challenge", "copy", "create", "list", "get", "delete", "records", "verify")
 }
 
 func TestDomainsCreate(t *testing.T) {
@@ -49,6 +58,94 @@ func TestDomainsCreate(t *testing.T) {
 	})
 }
 
+func TestDomainsCreateWithWWW(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		dcr := &godo.DomainCreateRequest{Name: "example.com"}
+		tm.domains.On("Create", dcr).Return(&testDomain, nil)
+		drcr := &godo.DomainRecordEditRequest{Type: "CNAME", Name: "www", Data: "example.com."}
+		tm.domains.On("CreateRecord", "example.com", drcr).Return(&testRecord, nil)
+
+		config.Args = append(config.Args, testDomain.Name)
+		config.Doit.Set(config.NS, doctl.ArgCreateWWW, true)
+		err := RunDomainCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDomainsCreateFromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "domains")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("# agency zones\nexample.com\n\nexample.net 10.0.0.2\nexample.org\n")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("Create", &godo.DomainCreateRequest{Name: "example.com", IPAddress: "10.0.0.1"}).Return(&testDomain, nil)
+		tm.domains.On("Create", &godo.DomainCreateRequest{Name: "example.net", IPAddress: "10.0.0.2"}).Return(&testDomain, nil)
+		tm.domains.On("Create", &godo.DomainCreateRequest{Name: "example.org", IPAddress: "10.0.0.1"}).Return(nil, errors.New("already exists"))
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFromFile, f.Name())
+		config.Doit.Set(config.NS, doctl.ArgIPAddress, "10.0.0.1")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDomainCreate(config)
+		assert.EqualError(t, err, "failed to create 1 of 3 domains")
+
+		expected := []string{"example.com", "created", "example.net", "created", "example.org", "failed", "already", "exists"}
+		assert.Equal(t, expected, strings.Fields(buf.String()))
+	})
+}
+
+func TestDomainsVerify(t *testing.T) {
+	cases := []struct {
+		hosts     []string
+		delegated bool
+		expected  []string
+	}{
+		{
+			hosts:     []string{"ns1.digitalocean.com.", "ns2.digitalocean.com.", "NS3.digitalocean.com."},
+			delegated: true,
+			expected:  []string{"ns1.digitalocean.com", "ok", "ns2.digitalocean.com", "ok", "ns3.digitalocean.com", "ok"},
+		},
+		{
+			hosts:    []string{"ns1.digitalocean.com.", "ns1.example.net."},
+			expected: []string{"ns1.digitalocean.com", "ok", "ns1.example.net", "foreign", "ns2.digitalocean.com", "missing", "ns3.digitalocean.com", "missing"},
+		},
+	}
+
+	defer func(f func(string) ([]*net.NS, error)) { lookupNSFunc = f }(lookupNSFunc)
+
+	for _, c := range cases {
+		lookupNSFunc = func(name string) ([]*net.NS, error) {
+			assert.Equal(t, "example.com", name)
+			var out []*net.NS
+			for _, h := range c.hosts {
+				out = append(out, &net.NS{Host: h})
+			}
+			return out, nil
+		}
+
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			var buf bytes.Buffer
+			config.Out = &buf
+			config.Args = append(config.Args, "example.com")
+			config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+			err := RunDomainVerify(config)
+			if c.delegated {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, c.expected, strings.Fields(buf.String()))
+		})
+	}
+}
+
 func TestDomainsList(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.domains.On("List").Return(testDomainList, nil)
@@ -68,6 +165,28 @@ func TestDomainsGet(t *testing.T) {
 	})
 }
 
+func TestDomainsGetZone(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		d := do.Domain{Domain: &godo.Domain{Name: "example.com", TTL: 1800, ZoneFile: "$ORIGIN example.com.\n$TTL 1800\n" +
+			"example.com. IN SOA ns1.digitalocean.com. hostmaster.example.com. 1415982609 10800 3600 604800 1800\n" +
+			"example.com. 1800 IN NS ns1.digitalocean.com.\n" +
+			"example.com. 1800 IN NS ns2.digitalocean.com.\n" +
+			"sub.example.com. 1800 IN NS ns1.example.net.\n" +
+			"example.com. 1800 IN A 10.0.0.1\n"}}
+		tm.domains.On("Get", "example.com").Return(&d, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgFormat, "SOA,Nameservers")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDomainGet(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "ns1.digitalocean.com. hostmaster.example.com. 1415982609 10800 3600 604800 1800\tns1.digitalocean.com.,ns2.digitalocean.com.\n", buf.String())
+	})
+}
+
 func TestDomainsGet_DomainRequired(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		err := RunDomainGet(config)
@@ -127,6 +246,209 @@ func TestRecordsCreate(t *testing.T) {
 	})
 }
 
+func TestRecordsCreateVerify(t *testing.T) {
+	defer func(f func(string, string, string) ([]string, error), d time.Duration, w io.Writer) {
+		queryRecordFunc, verifyInterval, verifyOut = f, d, w
+	}(queryRecordFunc, verifyInterval, verifyOut)
+	verifyInterval = time.Millisecond
+	verifyOut = ioutil.Discard
+
+	var queried []string
+	attempts := 0
+	queryRecordFunc = func(server, fqdn, rtype string) ([]string, error) {
+		assert.Equal(t, "www.example.com", fqdn)
+		assert.Equal(t, "A", rtype)
+		queried = append(queried, server)
+
+		attempts++
+		if attempts < 2 {
+			return nil, errors.New("no such host")
+		}
+		return []string{"192.168.1.1"}, nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		r := do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "192.168.1.1"}}
+		dcer := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "192.168.1.1"}
+		tm.domains.On("CreateRecord", "example.com", dcer).Return(&r, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordType, "A")
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgRecordData, "192.168.1.1")
+		config.Doit.Set(config.NS, doctl.ArgRecordVerify, true)
+		config.Doit.Set(config.NS, doctl.ArgRecordVerifyTimeout, 5)
+		config.Doit.Set(config.NS, doctl.ArgRecordResolvers, []string{"8.8.8.8"})
+
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordCreate(config)
+		assert.NoError(t, err)
+		expected := []string{"ns1.digitalocean.com", "ns1.digitalocean.com", "ns2.digitalocean.com", "ns3.digitalocean.com", "8.8.8.8"}
+		assert.Equal(t, expected, queried)
+	})
+}
+
+func TestVerifyRecordTimeout(t *testing.T) {
+	defer func(f func(string, string, string) ([]string, error), d time.Duration, w io.Writer) {
+		queryRecordFunc, verifyInterval, verifyOut = f, d, w
+	}(queryRecordFunc, verifyInterval, verifyOut)
+	verifyInterval = time.Millisecond
+	verifyOut = ioutil.Discard
+
+	queryRecordFunc = func(server, fqdn, rtype string) ([]string, error) {
+		assert.Equal(t, "example.com", fqdn)
+		return []string{"10.0.0.1"}, nil
+	}
+
+	r := &do.DomainRecord{DomainRecord: &godo.DomainRecord{Type: "A", Name: "@", Data: "192.168.1.1"}}
+	err := verifyRecord(context.Background(), "example.com", r, []string{"ns1.digitalocean.com"}, 10*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestRecordSwitch(t *testing.T) {
+	defer func(f func(string, string, string) ([]string, error), d time.Duration, w io.Writer) {
+		queryRecordFunc, verifyInterval, verifyOut = f, d, w
+	}(queryRecordFunc, verifyInterval, verifyOut)
+	verifyInterval = time.Millisecond
+	verifyOut = ioutil.Discard
+
+	defer func(f func(context.Context, time.Duration) error) { ttlExpirySleep = f }(ttlExpirySleep)
+	var slept []time.Duration
+	ttlExpirySleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	queryRecordFunc = func(server, fqdn, rtype string) ([]string, error) {
+		assert.Equal(t, "www.example.com", fqdn)
+		return []string{"10.0.0.2"}, nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "api", Data: "10.0.0.1"}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "A", Name: "www", Data: "10.0.0.3"}},
+		}
+		switched := do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.2"}}
+		tm.domains.On("Records", "example.com").Return(list, nil)
+		tm.domains.On("RecordTTL", "example.com", 1).Return(1800, nil)
+		tm.domains.On("EditRecordTTL", "example.com", 1, 30).Return(nil).Once()
+		tm.domains.On("EditRecord", "example.com", 1, &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.2"}).Return(&switched, nil)
+		tm.domains.On("EditRecordTTL", "example.com", 1, 1800).Return(nil).Once()
+
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgFromIP, "10.0.0.1")
+		config.Doit.Set(config.NS, doctl.ArgToIP, "10.0.0.2")
+		config.Doit.Set(config.NS, doctl.ArgRecordTTL, 30)
+		config.Doit.Set(config.NS, doctl.ArgRestoreTTL, true)
+		config.Doit.Set(config.NS, doctl.ArgRecordVerify, true)
+		config.Doit.Set(config.NS, doctl.ArgRecordVerifyTimeout, 5)
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordSwitch(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{1800 * time.Second}, slept)
+		tm.domains.AssertNumberOfCalls(t, "EditRecord", 1)
+	})
+}
+
+func TestRecordSwitchLowTTL(t *testing.T) {
+	defer func(f func(context.Context, time.Duration) error) { ttlExpirySleep = f }(ttlExpirySleep)
+	ttlExpirySleep = func(ctx context.Context, d time.Duration) error {
+		t.Errorf("unexpected wait of %s", d)
+		return nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}},
+		}
+		switched := do.DomainRecord{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.2"}}
+		tm.domains.On("Records", "example.com").Return(list, nil)
+		tm.domains.On("RecordTTL", "example.com", 1).Return(30, nil)
+		tm.domains.On("EditRecord", "example.com", 1, &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.2"}).Return(&switched, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgFromIP, "10.0.0.1")
+		config.Doit.Set(config.NS, doctl.ArgToIP, "10.0.0.2")
+		config.Doit.Set(config.NS, doctl.ArgRecordTTL, 60)
+		config.Doit.Set(config.NS, doctl.ArgRestoreTTL, true)
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordSwitch(config)
+		assert.NoError(t, err)
+		tm.domains.AssertNotCalled(t, "EditRecordTTL")
+	})
+}
+
+func TestRecordSwitchAlreadySwitched(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.2"}},
+		}
+		tm.domains.On("Records", "example.com").Return(list, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgFromIP, "10.0.0.1")
+		config.Doit.Set(config.NS, doctl.ArgToIP, "10.0.0.2")
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordSwitch(config)
+		assert.NoError(t, err)
+		tm.domains.AssertNotCalled(t, "EditRecord")
+	})
+}
+
+func TestRecordSwitchErrors(t *testing.T) {
+	cases := []struct {
+		from, to string
+		lookup   bool
+	}{
+		{"10.0.0.1", "not-an-ip", false},
+		{"10.0.0.1", "2001:db8::1", false},
+		{"10.0.0.9", "10.0.0.2", true},
+	}
+
+	for _, tc := range cases {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			if tc.lookup {
+				tm.domains.On("Records", "example.com").Return(do.DomainRecords{
+					{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}},
+				}, nil)
+			}
+
+			config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+			config.Doit.Set(config.NS, doctl.ArgFromIP, tc.from)
+			config.Doit.Set(config.NS, doctl.ArgToIP, tc.to)
+			config.Args = append(config.Args, "example.com")
+
+			err := RunRecordSwitch(config)
+			assert.Error(t, err, tc.to)
+		})
+	}
+}
+
+func TestRecordsGet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("Record", "example.com", 1).Return(&testRecord, nil)
+
+		config.Args = append(config.Args, "example.com", "1")
+
+		err := RunRecordGet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRecordsGetInvalidID(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "example.com", "www")
+
+		err := RunRecordGet(config)
+		assert.Error(t, err)
+	})
+}
+
 func TestRecordCreate_RequiredArguments(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		err := RunRecordCreate(config)
@@ -161,3 +483,45 @@ func TestRecordsUpdate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRecordsUpdateBySelector(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "@", Data: "10.0.0.1"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "www", Data: "10.0.0.1"}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "AAAA", Name: "www", Data: "::1"}},
+		}
+		tm.domains.On("Records", "example.com").Return(list, nil)
+
+		dcer := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "10.0.0.2"}
+		tm.domains.On("EditRecord", "example.com", 2, dcer).Return(&testRecord, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordType, "A")
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgRecordData, "10.0.0.2")
+
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordUpdate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRecordsUpdateBySelectorAmbiguous(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "10.0.0.1"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "www", Data: "10.0.0.2"}},
+		}
+		tm.domains.On("Records", "example.com").Return(list, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordType, "A")
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "www")
+		config.Doit.Set(config.NS, doctl.ArgRecordData, "10.0.0.3")
+
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordUpdate(config)
+		assert.Error(t, err)
+	})
+}
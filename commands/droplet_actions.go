@@ -73,6 +73,15 @@ func DropletAction() *Command {
 		displayerType(&action{}), docCategories("droplet"))
 	AddBoolFlag(cmdDropletActionReboot, doctl.ArgCommandWait, false, "Wait for action to complete")
 
+	cmdDropletActionRollingReboot := CmdBuilder(cmd, RunDropletActionRollingReboot,
+		"rolling-reboot", "reboot tagged droplets in batches, waiting for each batch to be healthy", Writer,
+		displayerType(&action{}), docCategories("droplet"))
+	AddStringFlag(cmdDropletActionRollingReboot, doctl.ArgTagName, "", "Tag of the droplets to reboot", requiredOpt())
+	AddIntFlag(cmdDropletActionRollingReboot, doctl.ArgBatchSize, 1, "Number of droplets to reboot at once")
+	AddIntFlag(cmdDropletActionRollingReboot, doctl.ArgWaitHealthyPort, 0, "Port on the droplets' public IPv4 address that must accept connections before the next batch")
+	AddStringFlag(cmdDropletActionRollingReboot, doctl.ArgWaitHealthyPath, "", "HTTP path on the health port that must answer with a 2xx or 3xx status")
+	AddIntFlag(cmdDropletActionRollingReboot, doctl.ArgTimeout, 300, "Seconds to wait for each batch to reboot and become healthy")
+
 	cmdDropletActionPowerCycle := CmdBuilder(cmd, RunDropletActionPowerCycle,
 		"power-cycle <droplet-id>", "power cycle droplet", Writer,
 		displayerType(&action{}), docCategories("droplet"))
@@ -0,0 +1,244 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+)
+
+var (
+	healthProbeFunc     = probeHealth
+	healthProbeInterval = 2 * time.Second
+)
+
+// probeHealth checks that addr accepts TCP connections, or when path is
+// set, that an HTTP GET of path answers with a 2xx or 3xx status.
+func probeHealth(addr, path string) error {
+	if path == "" {
+		conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get("http://" + addr + path)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s%s answered %s", addr, path, resp.Status)
+	}
+
+	return nil
+}
+
+// RunDropletActionRollingReboot reboots the droplets with a tag a batch at
+// a time. Every droplet in a batch is rebooted at once, then the reboot
+// actions and health probes are waited on together, within timeout seconds
+// for the batch, before starting the next batch. It stops at the first
+// batch that doesn't make it, displaying the reboots that completed.
+func RunDropletActionRollingReboot(c *CmdConfig) error {
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
+	}
+	if tagName == "" {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	batchSize, err := c.Doit.GetInt(c.NS, doctl.ArgBatchSize)
+	if err != nil {
+		return err
+	}
+	if batchSize < 1 {
+		return fmt.Errorf("%s must be at least 1", doctl.ArgBatchSize)
+	}
+
+	port, err := c.Doit.GetInt(c.NS, doctl.ArgWaitHealthyPort)
+	if err != nil {
+		return err
+	}
+
+	path, err := c.Doit.GetString(c.NS, doctl.ArgWaitHealthyPath)
+	if err != nil {
+		return err
+	}
+	if path != "" && port == 0 {
+		return fmt.Errorf("%s needs %s", doctl.ArgWaitHealthyPath, doctl.ArgWaitHealthyPort)
+	}
+
+	timeout, err := c.Doit.GetInt(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+
+	list, err := c.Droplets().ListByTag(tagName)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return fmt.Errorf("no droplets are tagged %q", tagName)
+	}
+
+	done := do.Actions{}
+
+	for start := 0; start < len(list); start += batchSize {
+		end := start + batchSize
+		if end > len(list) {
+			end = len(list)
+		}
+
+		completed, err := rebootBatch(c, list[start:end], port, path, timeout)
+		done = append(done, completed...)
+		if err != nil {
+			if len(done) > 0 {
+				c.Display(&action{actions: done})
+			}
+			return err
+		}
+	}
+
+	return c.Display(&action{actions: done})
+}
+
+// rebootBatch reboots every droplet in batch, then waits for the reboots
+// and, when port is set, the health probes, giving up after timeout
+// seconds. It returns the reboot actions that completed and the first
+// failure. Reboots that were issued are waited on even if a later one in
+// the batch couldn't be.
+func rebootBatch(c *CmdConfig, batch do.Droplets, port int, path string, timeout int) (do.Actions, error) {
+	das := c.DropletActions()
+
+	var rebooting do.Droplets
+	var actionIDs []int
+	var rebootErr error
+
+	for _, d := range batch {
+		a, err := das.Reboot(d.ID)
+		if err != nil {
+			rebootErr = fmt.Errorf("unable to reboot droplet %d: %v", d.ID, err)
+			break
+		}
+
+		rebooting = append(rebooting, d)
+		actionIDs = append(actionIDs, a.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	bc := *c
+	bc.Context = ctx
+
+	actions := make([]*do.Action, len(rebooting))
+	errs := make([]error, len(rebooting))
+
+	var wg sync.WaitGroup
+	for i := range rebooting {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			actions[i], errs[i] = waitRebooted(&bc, &rebooting[i], actionIDs[i], port, path)
+		}(i)
+	}
+	wg.Wait()
+
+	completed := do.Actions{}
+	for _, a := range actions {
+		if a != nil && a.Status != "errored" {
+			completed = append(completed, *a)
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return completed, err
+		}
+	}
+
+	return completed, rebootErr
+}
+
+// waitRebooted waits for a droplet's reboot action and, when port is set,
+// for it to pass the health probe, until c's context is done. The action
+// is returned whenever it finished, even if the droplet isn't healthy.
+func waitRebooted(c *CmdConfig, d *do.Droplet, actionID, port int, path string) (*do.Action, error) {
+	a, err := actionWait(c, actionID, 5)
+	if err == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out waiting for droplet %d to reboot, stopping", d.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == "errored" {
+		return a, fmt.Errorf("reboot of droplet %d errored, stopping", d.ID)
+	}
+
+	if port == 0 {
+		return a, nil
+	}
+
+	return a, waitDropletHealthy(c.ctx(), d, port, path)
+}
+
+// waitDropletHealthy probes a droplet until it passes or ctx is done. When
+// ctx's deadline passes first, the last probe's error is reported.
+func waitDropletHealthy(ctx context.Context, d *do.Droplet, port int, path string) error {
+	ip, err := d.PublicIPv4()
+	if err != nil || ip == "" {
+		return fmt.Errorf("droplet %d has no public IPv4 address to probe", d.ID)
+	}
+	addr := net.JoinHostPort(ip, strconv.Itoa(port))
+
+	p := startProgress("droplet %d health", d.ID)
+	done := inFlight.track("droplet %d becoming healthy after reboot", d.ID)
+	defer done()
+
+	for {
+		err := healthProbeFunc(addr, path)
+		if err == nil {
+			p.done("healthy")
+			return nil
+		}
+
+		p.update("waiting")
+		if ctxErr := sleepContext(ctx, healthProbeInterval); ctxErr != nil {
+			if ctxErr == context.DeadlineExceeded {
+				p.done("unhealthy")
+				return fmt.Errorf("droplet %d was not healthy in time, stopping: %v", d.ID, err)
+			}
+			p.done("cancelled")
+			return ctxErr
+		}
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func taggedDroplet(id int, ip string) do.Droplet {
+	return do.Droplet{Droplet: &godo.Droplet{
+		ID: id,
+		Networks: &godo.Networks{
+			V4: []godo.NetworkV4{{IPAddress: ip, Type: "public"}},
+		},
+	}}
+}
+
+func TestRollingReboot(t *testing.T) {
+	defer func(f func(string, string) error, d time.Duration) {
+		healthProbeFunc, healthProbeInterval = f, d
+	}(healthProbeFunc, healthProbeInterval)
+	healthProbeInterval = time.Millisecond
+
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+
+	healthProbeFunc = func(addr, path string) error {
+		assert.Equal(t, "/healthz", path)
+		record("probe " + addr)
+		return nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Droplets{taggedDroplet(1, "10.0.0.1"), taggedDroplet(2, "10.0.0.2"), taggedDroplet(3, "10.0.0.3")}
+		tm.droplets.On("ListByTag", "web").Return(list, nil)
+		for _, d := range list {
+			id := d.ID
+			tm.dropletActions.On("Reboot", id).Return(&testAction, nil).Run(func(mock.Arguments) {
+				record("reboot")
+			})
+		}
+		tm.actions.On("Get", testAction.ID).Return(&testAction, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgBatchSize, 2)
+		config.Doit.Set(config.NS, doctl.ArgWaitHealthyPort, 80)
+		config.Doit.Set(config.NS, doctl.ArgWaitHealthyPath, "/healthz")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5)
+
+		err := RunDropletActionRollingReboot(config)
+		assert.NoError(t, err)
+		require.Len(t, events, 6)
+		assert.Equal(t, []string{"reboot", "reboot"}, events[:2])
+		sort.Strings(events[2:4])
+		assert.Equal(t, []string{"probe 10.0.0.1:80", "probe 10.0.0.2:80"}, events[2:4])
+		assert.Equal(t, []string{"reboot", "probe 10.0.0.3:80"}, events[4:])
+	})
+}
+
+func TestRollingRebootStopsWhenUnhealthy(t *testing.T) {
+	defer func(f func(string, string) error, d time.Duration) {
+		healthProbeFunc, healthProbeInterval = f, d
+	}(healthProbeFunc, healthProbeInterval)
+	healthProbeInterval = time.Millisecond
+	healthProbeFunc = func(addr, path string) error {
+		if addr == "10.0.0.2:22" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Droplets{taggedDroplet(1, "10.0.0.1"), taggedDroplet(2, "10.0.0.2"), taggedDroplet(3, "10.0.0.3")}
+		tm.droplets.On("ListByTag", "web").Return(list, nil)
+		for _, d := range list[:2] {
+			a := do.Action{Action: &godo.Action{ID: 100 + d.ID, Status: "completed"}}
+			tm.dropletActions.On("Reboot", d.ID).Return(&a, nil)
+			tm.actions.On("Get", a.ID).Return(&a, nil)
+		}
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgBatchSize, 2)
+		config.Doit.Set(config.NS, doctl.ArgWaitHealthyPort, 22)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 0)
+
+		err := RunDropletActionRollingReboot(config)
+		assert.EqualError(t, err, "droplet 2 was not healthy in time, stopping: connection refused")
+		tm.dropletActions.AssertNotCalled(t, "Reboot", 3)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "101", strings.Fields(lines[0])[0])
+		assert.Equal(t, "102", strings.Fields(lines[1])[0])
+	})
+}
+
+func TestRollingRebootArguments(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		assert.Error(t, RunDropletActionRollingReboot(config))
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgBatchSize, 0)
+		assert.Error(t, RunDropletActionRollingReboot(config))
+
+		config.Doit.Set(config.NS, doctl.ArgBatchSize, 1)
+		config.Doit.Set(config.NS, doctl.ArgWaitHealthyPath, "/healthz")
+		assert.Error(t, RunDropletActionRollingReboot(config))
+	})
+}
+
+func TestProbeHealth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	addr := strings.TrimPrefix(ts.URL, "http://")
+	assert.NoError(t, probeHealth(addr, ""))
+	assert.NoError(t, probeHealth(addr, "/healthz"))
+	assert.Error(t, probeHealth(addr, "/down"))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := l.Addr().String()
+	l.Close()
+	assert.Error(t, probeHealth(closed, ""))
+}
@@ -14,13 +14,18 @@ limitations under the License.
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
+	"time"
 
+	"github.com/bryanl/webbrowser"
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
@@ -29,6 +34,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	consoleBaseURL = "https://cloud.digitalocean.com"
+
+	consoleBrowserOpen = func(u string) error {
+		return webbrowser.Open(u, webbrowser.NewTab, true)
+	}
+)
+
 // Droplet creates the droplet command.
 func Droplet() *Command {
 	cmd := &Command{
@@ -42,18 +55,24 @@ func Droplet() *Command {
 		IsIndex:       true,
 	}
 
-	CmdBuilder(cmd, RunDropletActions, "actions <droplet id>", "droplet actions", Writer,
+	cmdDropletActions := CmdBuilder(cmd, RunDropletActions, "actions <droplet id>", "droplet actions", Writer,
 		aliasOpt("a"), displayerType(&action{}), docCategories("droplet"))
+	addActionFilterFlags(cmdDropletActions)
 
 	CmdBuilder(cmd, RunDropletBackups, "backups <droplet id>", "droplet backups", Writer,
 		aliasOpt("b"), displayerType(&image{}), docCategories("droplet"))
 
+	cmdDropletConsole := CmdBuilder(cmd, RunDropletConsole, "console <droplet id or name>", "open the droplet's web console", Writer,
+		docCategories("droplet"))
+	AddBoolFlag(cmdDropletConsole, doctl.ArgNoBrowser, false, "Print the console URL without opening a browser")
+
 	cmdDropletCreate := CmdBuilder(cmd, RunDropletCreate, "create NAME [NAME ...]", "create droplet", Writer,
 		aliasOpt("c"), displayerType(&droplet{}), docCategories("droplet"))
-	AddStringSliceFlag(cmdDropletCreate, doctl.ArgSSHKeys, []string{}, "SSH Keys or fingerprints")
+	AddStringSliceFlag(cmdDropletCreate, doctl.ArgSSHKeys, []string{}, "SSH key IDs, fingerprints, names or public key files")
 	AddStringFlag(cmdDropletCreate, doctl.ArgUserData, "", "User data")
 	AddStringFlag(cmdDropletCreate, doctl.ArgUserDataFile, "", "User data file")
 	AddBoolFlag(cmdDropletCreate, doctl.ArgCommandWait, false, "Wait for droplet to be created")
+	AddIntFlag(cmdDropletCreate, doctl.ArgTimeout, 300, "Seconds to wait for droplets to become active, 0 waits forever")
 	AddStringFlag(cmdDropletCreate, doctl.ArgRegionSlug, "", "Droplet region",
 		requiredOpt())
 	AddStringFlag(cmdDropletCreate, doctl.ArgSizeSlug, "", "Droplet size",
@@ -61,17 +80,18 @@ func Droplet() *Command {
 	AddBoolFlag(cmdDropletCreate, doctl.ArgBackups, false, "Backup droplet")
 	AddBoolFlag(cmdDropletCreate, doctl.ArgIPv6, false, "IPv6 support")
 	AddBoolFlag(cmdDropletCreate, doctl.ArgPrivateNetworking, false, "Private networking")
-	AddStringFlag(cmdDropletCreate, doctl.ArgImage, "", "Droplet image",
+	AddStringFlag(cmdDropletCreate, doctl.ArgImage, "", "Droplet image ID, slug or snapshot name",
 		requiredOpt())
 	AddStringFlag(cmdDropletCreate, doctl.ArgTagName, "", "Tag name")
 
-	AddStringSliceFlag(cmdDropletCreate, doctl.ArgVolumeList, []string{}, "Volumes to attach",
-		betaOpt()) // TODO(antoine): remove once out of beta
+	AddStringSliceFlag(cmdDropletCreate, doctl.ArgVolumeList, []string{}, "Volume IDs or names to attach")
 
-	CmdBuilder(cmd, RunDropletDelete, "delete ID [ID|Name ...]", "Delete droplet by id or name", Writer,
+	cmdDropletDelete := CmdBuilder(cmd, RunDropletDelete, "delete ID [ID|Name ...]", "Delete droplet by id or name", Writer,
 		aliasOpt("d", "del", "rm"), docCategories("droplet"))
+	AddBoolFlag(cmdDropletDelete, doctl.ArgGraceful, false, "Shut droplets down before deleting them")
+	AddIntFlag(cmdDropletDelete, doctl.ArgTimeout, 60, "Seconds to wait for a graceful shutdown before powering off")
 
-	CmdBuilder(cmd, RunDropletGet, "get", "get droplet", Writer,
+	CmdBuilder(cmd, RunDropletGet, "get ID|Name [ID|Name ...]", "get droplets by id or name", Writer,
 		aliasOpt("g"), displayerType(&droplet{}), docCategories("droplet"))
 
 	CmdBuilder(cmd, RunDropletKernels, "kernels <droplet id>", "droplet kernels", Writer,
@@ -81,10 +101,25 @@ func Droplet() *Command {
 		aliasOpt("ls"), displayerType(&droplet{}), docCategories("droplet"))
 	AddStringFlag(cmdRunDropletList, doctl.ArgRegionSlug, "", "Droplet region")
 	AddStringFlag(cmdRunDropletList, doctl.ArgTagName, "", "Tag name")
+	AddStringFlag(cmdRunDropletList, doctl.ArgName, "", "Droplet name")
+	AddStringFlag(cmdRunDropletList, doctl.ArgNameRegex, "", "Regular expression droplet names must match")
+
+	cmdDropletSummary := CmdBuilder(cmd, RunDropletSummary, "summary", "count droplets by region, size and tag", Writer,
+		displayerType(&dropletFleet{}), docCategories("droplet"))
+	AddStringFlag(cmdDropletSummary, doctl.ArgTagName, "", "Only count droplets with this tag")
 
 	CmdBuilder(cmd, RunDropletNeighbors, "neighbors <droplet id>", "droplet neighbors", Writer,
 		aliasOpt("n"), displayerType(&droplet{}), docCategories("droplet"))
 
+	CmdBuilder(cmd, RunDropletReverseDNS, "reverse-dns <droplet id or name>", "show the reverse DNS of a droplet's public addresses", Writer,
+		aliasOpt("ptr"), displayerType(&reverseDNS{}), docCategories("droplet"))
+
+	cmdDropletSetReverseDNS := CmdBuilder(cmd, RunDropletSetReverseDNS, "set-reverse-dns <droplet id or name> <hostname>",
+		"set a droplet's reverse DNS by renaming it to hostname", Writer,
+		displayerType(&action{}), docCategories("droplet"))
+	AddBoolFlag(cmdDropletSetReverseDNS, doctl.ArgForce, false, "Rename even if hostname doesn't resolve to the droplet")
+	AddBoolFlag(cmdDropletSetReverseDNS, doctl.ArgCommandWait, false, "Wait for action to complete")
+
 	CmdBuilder(cmd, RunDropletSnapshots, "snapshots <droplet id>", "snapshots", Writer,
 		aliasOpt("s"), displayerType(&image{}), docCategories("droplet"))
 
@@ -95,7 +130,20 @@ func Droplet() *Command {
 
 	cmdRunDropletUntag := CmdBuilder(cmd, RunDropletUntag, "untag <droplet id or name>", "untag", Writer,
 		docCategories("droplet"))
-	AddStringSliceFlag(cmdRunDropletUntag, doctl.ArgDropletName, []string{}, "Droplet names")
+	AddStringFlag(cmdRunDropletUntag, doctl.ArgTagName, "", "Tag name")
+	AddStringSliceFlag(cmdRunDropletUntag, doctl.ArgDropletName, []string{}, "Droplet names (deprecated, use arguments with --tag-name)")
+
+	cmdRunDropletSnapshotRotate := CmdBuilder(cmd, RunDropletSnapshotRotate, "snapshot-rotate <droplet id or tag>",
+		"snapshot droplets and delete their oldest rotated snapshots", Writer,
+		displayerType(&snapshotPlan{}), docCategories("droplet", "snapshot"))
+	AddIntFlag(cmdRunDropletSnapshotRotate, doctl.ArgKeep, 7, "Number of rotated snapshots to keep for each droplet")
+	AddStringFlag(cmdRunDropletSnapshotRotate, doctl.ArgNameTemplate, defaultSnapshotNameTemplate,
+		"Snapshot name template, fields: .DropletID .DropletName .Date .Time .Timestamp")
+
+	cmdRunDropletWait := CmdBuilder(cmd, RunDropletWait, "wait <droplet id or name>", "wait for a droplet to reach a status", Writer,
+		aliasOpt("w"), displayerType(&droplet{}), docCategories("droplet"))
+	AddStringFlag(cmdRunDropletWait, doctl.ArgDropletStatus, "active", "Status to wait for [new|active|off|archive]")
+	AddIntFlag(cmdRunDropletWait, doctl.ArgTimeout, 300, "Seconds to wait before giving up, 0 waits forever")
 
 	return cmd
 }
@@ -111,6 +159,17 @@ func RunDropletActions(c *CmdConfig) error {
 	}
 
 	list, err := ds.Actions(id)
+	if err != nil {
+		return err
+	}
+
+	list, err = filterActionList(c, list)
+	if err != nil {
+		return err
+	}
+
+	sort.Stable(actionsByCompletedAt(list))
+
 	item := &action{actions: list}
 	return c.Display(item)
 }
@@ -134,6 +193,56 @@ func RunDropletBackups(c *CmdConfig) error {
 	return c.Display(item)
 }
 
+// RunDropletConsole opens the web console of a droplet, which works when
+// the droplet can't be reached over SSH.
+func RunDropletConsole(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	noBrowser, err := c.Doit.GetBool(c.NS, doctl.ArgNoBrowser)
+	if err != nil {
+		return err
+	}
+
+	d, err := resolveDroplet(c.Droplets(), c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/droplets/%d/console", consoleBaseURL, d.ID)
+	fmt.Fprintln(c.Out, u)
+
+	if noBrowser {
+		return nil
+	}
+
+	return consoleBrowserOpen(u)
+}
+
+// resolveDroplet finds a single droplet by id or name.
+func resolveDroplet(ds do.DropletsService, arg string) (*do.Droplet, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return ds.Get(id)
+	}
+
+	all, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := resolveDroplets([]string{arg}, all)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 1 {
+		return nil, fmt.Errorf("droplet name %q is ambiguous, use a droplet id", arg)
+	}
+
+	return &list[0], nil
+}
+
 // RunDropletCreate creates a droplet.
 func RunDropletCreate(c *CmdConfig) error {
 
@@ -151,6 +260,11 @@ func RunDropletCreate(c *CmdConfig) error {
 		return err
 	}
 
+	err = checkGPUSizeRegion(c.Sizes(), size, region)
+	if err != nil {
+		return err
+	}
+
 	backups, err := c.Doit.GetBool(c.NS, doctl.ArgBackups)
 	if err != nil {
 		return err
@@ -176,7 +290,10 @@ func RunDropletCreate(c *CmdConfig) error {
 		return err
 	}
 
-	sshKeys := extractSSHKeys(keys)
+	sshKeys, err := resolveSSHKeys(c.Keys(), extractSSHKeys(keys))
+	if err != nil {
+		return err
+	}
 
 	userData, err := c.Doit.GetString(c.NS, doctl.ArgUserData)
 	if err != nil {
@@ -199,13 +316,14 @@ func RunDropletCreate(c *CmdConfig) error {
 		return err
 	}
 
-	var createImage godo.DropletCreateImage
-
 	imageStr, err := c.Doit.GetString(c.NS, doctl.ArgImage)
-	if i, err := strconv.Atoi(imageStr); err == nil {
-		createImage = godo.DropletCreateImage{ID: i}
-	} else {
-		createImage = godo.DropletCreateImage{Slug: imageStr}
+	if err != nil {
+		return err
+	}
+
+	createImage, err := resolveCreateImage(c.Images(), imageStr)
+	if err != nil {
+		return err
 	}
 
 	wait, err := c.Doit.GetBool(c.NS, doctl.ArgCommandWait)
@@ -213,12 +331,19 @@ func RunDropletCreate(c *CmdConfig) error {
 		return err
 	}
 
+	timeout, err := c.Doit.GetInt(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+
 	ds := c.Droplets()
 	ts := c.Tags()
 
 	var wg sync.WaitGroup
 	errs := make(chan error, len(c.Args))
-	for _, name := range c.Args {
+	created := make([]*do.Droplet, len(c.Args))
+	for i, name := range c.Args {
+		i := i
 		dcr := &godo.DropletCreateRequest{
 			Name:              name,
 			Region:            region,
@@ -235,12 +360,35 @@ func RunDropletCreate(c *CmdConfig) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			var p *progressTask
+			if wait {
+				done := inFlight.track("creating droplet %s", dcr.Name)
+				defer done()
+
+				p = startProgress("creating droplet %s", dcr.Name)
+			}
+
 			d, err := ds.Create(dcr, wait)
 			if err != nil {
+				if p != nil {
+					p.done("error")
+				}
 				errs <- err
 				return
 			}
 
+			if wait {
+				d, err = waitDropletStatus(c.ctx(), ds, d, "active", timeout, p)
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+
+			if p != nil {
+				p.done(d.Status)
+			}
+
 			if tagName != "" {
 				trr := &godo.TagResourcesRequest{
 					Resources: []godo.Resource{
@@ -255,14 +403,29 @@ func RunDropletCreate(c *CmdConfig) error {
 
 			}
 
-			item := &droplet{droplets: do.Droplets{*d}}
-			c.Display(item)
+			created[i] = d
 		}()
 	}
 
 	wg.Wait()
 	close(errs)
 
+	// created droplets are displayed together so json output is a single
+	// document, even when some creates failed.
+	var list do.Droplets
+	for _, d := range created {
+		if d != nil {
+			list = append(list, *d)
+		}
+	}
+
+	if len(list) > 0 {
+		err = c.Display(&droplet{droplets: list})
+		if err != nil {
+			return err
+		}
+	}
+
 	for err := range errs {
 		if err != nil {
 			return err
@@ -302,22 +465,41 @@ func RunDropletTag(c *CmdConfig) error {
 	return matchDroplets(c.Args, ds, fn)
 }
 
-// RunDropletUntag untags a droplet.
+// RunDropletUntag removes a tag from droplets. The droplets are given as
+// arguments and the tag with --tag-name, matching droplet tag. The older
+// form, with the tag as the argument and droplets in --droplet-name, is
+// still accepted.
 func RunDropletUntag(c *CmdConfig) error {
 	ds := c.Droplets()
 	ts := c.Tags()
 
-	if len(c.Args) != 1 {
-		return doctl.NewMissingArgsErr(c.NS)
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
 	}
 
-	tagName := c.Args[0]
-
 	dropletIDStrs, err := c.Doit.GetStringSlice(c.NS, doctl.ArgDropletName)
 	if err != nil {
 		return err
 	}
 
+	targets := c.Args
+	if tagName == "" && len(dropletIDStrs) > 0 {
+		if len(c.Args) != 1 {
+			return doctl.NewMissingArgsErr(c.NS)
+		}
+
+		warn("untag <tag> --droplet-name is deprecated, use untag <droplet> --tag-name <tag>")
+		tagName, targets = c.Args[0], dropletIDStrs
+	}
+
+	if tagName == "" {
+		return doctl.NewMissingArgsErr(fmt.Sprintf("%s.%s", c.NS, doctl.ArgTagName))
+	}
+	if len(targets) < 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
 	fn := func(ids []int) error {
 		urr := &godo.UntagResourcesRequest{}
 
@@ -333,7 +515,51 @@ func RunDropletUntag(c *CmdConfig) error {
 		return ts.UntagResources(tagName, urr)
 	}
 
-	return matchDroplets(dropletIDStrs, ds, fn)
+	return matchDroplets(targets, ds, fn)
+}
+
+// resolveCreateImage converts an image ID, snapshot name or slug into a
+// create image. Known slugs are used as is; anything else is matched against
+// the user's image names, which must be unique. If the user's images can't be
+// listed the value is passed on as a slug and left to the API.
+func resolveCreateImage(is do.ImagesService, image string) (godo.DropletCreateImage, error) {
+	if i, err := strconv.Atoi(image); err == nil {
+		return godo.DropletCreateImage{ID: i}, nil
+	}
+
+	if _, err := is.GetBySlug(image); err == nil {
+		return godo.DropletCreateImage{Slug: image}, nil
+	}
+
+	list, err := is.ListUser(false)
+	if err != nil {
+		return godo.DropletCreateImage{Slug: image}, nil
+	}
+
+	var matches []int
+	for _, i := range list {
+		if i.Name == image {
+			matches = append(matches, i.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return godo.DropletCreateImage{Slug: image}, nil
+	case 1:
+		return godo.DropletCreateImage{ID: matches[0]}, nil
+	default:
+		return godo.DropletCreateImage{}, fmt.Errorf("image name %q is ambiguous, matches images %s", image, joinInts(matches))
+	}
+}
+
+func joinInts(in []int) string {
+	out := make([]string, len(in))
+	for i := range in {
+		out[i] = strconv.Itoa(in[i])
+	}
+
+	return strings.Join(out, ", ")
 }
 
 func extractSSHKeys(keys []string) []godo.DropletCreateSSHKey {
@@ -430,11 +656,45 @@ func RunDropletDelete(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	} else if len(c.Args) > 0 && tagName != "" {
 		return fmt.Errorf("please specify droplets identifiers or a tag name")
-	} else if tagName != "" {
+	}
+
+	graceful, err := c.Doit.GetBool(c.NS, doctl.ArgGraceful)
+	if err != nil {
+		return err
+	}
+
+	timeout, err := c.Doit.GetInt(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+
+	if tagName != "" {
+		if graceful {
+			list, err := ds.ListByTag(tagName)
+			if err != nil {
+				return err
+			}
+
+			ids := []int{}
+			for _, d := range list {
+				ids = append(ids, d.ID)
+			}
+
+			if err := shutdownDroplets(c, ids, timeout); err != nil {
+				return err
+			}
+		}
+
 		return ds.DeleteByTag(tagName)
 	}
 
 	fn := func(ids []int) error {
+		if graceful {
+			if err := shutdownDroplets(c, ids, timeout); err != nil {
+				return err
+			}
+		}
+
 		for _, id := range ids {
 			if err := ds.Delete(id); err != nil {
 				return fmt.Errorf("unable to delete droplet %d: %v", id, err)
@@ -447,6 +707,104 @@ func RunDropletDelete(c *CmdConfig) error {
 	return matchDroplets(c.Args, ds, fn)
 }
 
+// shutdownDroplets shuts down the droplets with ids in parallel. A droplet
+// that isn't off after timeout seconds is powered off instead.
+func shutdownDroplets(c *CmdConfig, ids []int, timeout int) error {
+	ds, das := c.Droplets(), c.DropletActions()
+
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			errs[i] = shutdownDroplet(c.ctx(), ds, das, id, timeout)
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func shutdownDroplet(ctx context.Context, ds do.DropletsService, das do.DropletActionsService, id, timeout int) error {
+	d, err := ds.Get(id)
+	if err != nil {
+		return err
+	}
+	if d.Status == "off" {
+		return nil
+	}
+
+	p := startProgress("droplet %d", id)
+	done := inFlight.track("shutting down droplet %d", id)
+	defer done()
+
+	if _, err := das.Shutdown(id); err == nil {
+		p.update("shutting down")
+
+		off, err := waitDropletOff(ctx, ds, id, timeout)
+		if err != nil {
+			p.done("error")
+			return err
+		}
+		if off {
+			p.done("off")
+			return nil
+		}
+	}
+
+	p.update("powering off")
+	if _, err := das.PowerOff(id); err != nil {
+		p.done("error")
+		return fmt.Errorf("unable to power off droplet %d: %v", id, err)
+	}
+
+	off, err := waitDropletOff(ctx, ds, id, timeout)
+	if err != nil {
+		p.done("error")
+		return err
+	}
+	if !off {
+		p.done("timed out")
+		return fmt.Errorf("droplet %d did not power off, it was not deleted", id)
+	}
+
+	p.done("off")
+	return nil
+}
+
+// waitDropletOff polls a droplet until it is off or timeout seconds have
+// passed, and reports whether it is off. It stops with ctx's error when ctx
+// is done.
+func waitDropletOff(ctx context.Context, ds do.DropletsService, id, timeout int) (bool, error) {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+
+	for {
+		if err := sleepContext(ctx, dropletWaitInterval); err != nil {
+			return false, err
+		}
+
+		d, err := ds.Get(id)
+		if err != nil {
+			return false, err
+		}
+		if d.Status == "off" {
+			return true, nil
+		}
+
+		if !time.Now().Add(dropletWaitInterval).Before(deadline) {
+			return false, nil
+		}
+	}
+}
+
 type matchDropletsFn func(ids []int) error
 
 func matchDroplets(ids []string, ds do.DropletsService, fn matchDropletsFn) error {
@@ -490,24 +848,69 @@ func matchDroplets(ids []string, ds do.DropletsService, fn matchDropletsFn) erro
 	return fn(extractedIDs)
 }
 
-// RunDropletGet returns a droplet.
+// RunDropletGet returns droplets by id or name.
 func RunDropletGet(c *CmdConfig) error {
-	id, err := getDropletIDArg(c.NS, c.Args)
-	if err != nil {
-		return err
+	if len(c.Args) < 1 {
+		return doctl.NewMissingArgsErr(c.NS)
 	}
 
 	ds := c.Droplets()
 
-	d, err := ds.Get(id)
-	if err != nil {
-		return err
+	var list do.Droplets
+	if ids, err := allInt(c.Args); err == nil {
+		for _, id := range ids {
+			d, err := ds.Get(id)
+			if err != nil {
+				return err
+			}
+
+			list = append(list, *d)
+		}
+	} else {
+		// names are resolved with a single list call.
+		all, err := ds.List()
+		if err != nil {
+			return err
+		}
+
+		list, err = resolveDroplets(c.Args, all)
+		if err != nil {
+			return err
+		}
 	}
 
-	item := &droplet{droplets: do.Droplets{*d}}
+	item := &droplet{droplets: list}
 	return c.Display(item)
 }
 
+// resolveDroplets finds droplets by id or name in a list of droplets. Names
+// matching several droplets return all of them.
+func resolveDroplets(idsOrNames []string, all do.Droplets) (do.Droplets, error) {
+	var out do.Droplets
+	seen := map[int]bool{}
+
+	for _, in := range idsOrNames {
+		found := false
+		for _, d := range all {
+			if strconv.Itoa(d.ID) != in && d.Name != in {
+				continue
+			}
+
+			found = true
+			if !seen[d.ID] {
+				seen[d.ID] = true
+				out = append(out, d)
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("droplet %q could not be found", in)
+		}
+	}
+
+	return out, nil
+}
+
 // RunDropletKernels returns a list of available kernels for a droplet.
 func RunDropletKernels(c *CmdConfig) error {
 
@@ -541,6 +944,24 @@ func RunDropletList(c *CmdConfig) error {
 		return err
 	}
 
+	name, err := c.Doit.GetString(c.NS, doctl.ArgName)
+	if err != nil {
+		return err
+	}
+
+	nameRegex, err := c.Doit.GetString(c.NS, doctl.ArgNameRegex)
+	if err != nil {
+		return err
+	}
+
+	var nameRE *regexp.Regexp
+	if nameRegex != "" {
+		nameRE, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return fmt.Errorf("invalid name regex %q: %v", nameRegex, err)
+		}
+	}
+
 	matches := []glob.Glob{}
 	for _, globStr := range c.Args {
 		g, err := glob.Compile(globStr)
@@ -553,15 +974,17 @@ func RunDropletList(c *CmdConfig) error {
 
 	var matchedList do.Droplets
 
+	// tags are the only filter the API applies, the others are applied
+	// to the returned list.
 	var list do.Droplets
 	if tagName == "" {
 		list, err = ds.List()
-		if err != nil {
-			return err
-		}
 	} else {
 		list, err = ds.ListByTag(tagName)
 	}
+	if err != nil {
+		return err
+	}
 
 	for _, droplet := range list {
 		var skip = true
@@ -581,6 +1004,14 @@ func RunDropletList(c *CmdConfig) error {
 			}
 		}
 
+		if !skip && name != "" && name != droplet.Name {
+			skip = true
+		}
+
+		if !skip && nameRE != nil && !nameRE.MatchString(droplet.Name) {
+			skip = true
+		}
+
 		if !skip {
 			matchedList = append(matchedList, droplet)
 		}
@@ -660,3 +1091,263 @@ func buildDropletSummary(ds do.DropletsService) (*dropletSummary, error) {
 
 	return &sum, nil
 }
+
+// dropletStatuses are the statuses a droplet can be waited on to reach.
+var dropletStatuses = []string{"new", "active", "off", "archive"}
+
+// dropletWaitInterval is how often a waited on droplet is polled.
+var dropletWaitInterval = 5 * time.Second
+
+// RunDropletWait waits for a droplet to reach a status.
+func RunDropletWait(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	status, err := c.Doit.GetString(c.NS, doctl.ArgDropletStatus)
+	if err != nil {
+		return err
+	}
+
+	valid := false
+	for _, s := range dropletStatuses {
+		if status == s {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown droplet status %q, expected one of %s", status, strings.Join(dropletStatuses, ", "))
+	}
+
+	timeout, err := c.Doit.GetInt(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+
+	ds := c.Droplets()
+
+	d, err := resolveDroplet(ds, c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	p := startProgress("droplet %d", d.ID)
+	done := inFlight.track("droplet %d becoming %s", d.ID, status)
+	defer done()
+
+	d, err = waitDropletStatus(c.ctx(), ds, d, status, timeout, p)
+	if err != nil {
+		return err
+	}
+
+	p.done(d.Status)
+	return c.Display(&droplet{droplets: do.Droplets{*d}})
+}
+
+// waitDropletStatus polls d until it reaches status, giving up after
+// timeout seconds unless timeout is 0, or when ctx is done. A droplet that errors or is
+// archived on the way won't get there, so that is reported straight away.
+// p is updated with each status seen, and marked done on failure.
+func waitDropletStatus(ctx context.Context, ds do.DropletsService, d *do.Droplet, status string, timeout int, p *progressTask) (*do.Droplet, error) {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+
+	var err error
+	for d.Status != status {
+		if d.Status == "errored" || d.Status == "archive" {
+			p.done(d.Status)
+			return nil, fmt.Errorf("droplet %d is %s, it will not become %s", d.ID, d.Status, status)
+		}
+
+		if timeout > 0 && !time.Now().Add(dropletWaitInterval).Before(deadline) {
+			p.done(d.Status)
+			return nil, fmt.Errorf("timed out waiting for droplet %d to be %s, it is %s", d.ID, status, d.Status)
+		}
+
+		p.update(d.Status)
+		if err := sleepContext(ctx, dropletWaitInterval); err != nil {
+			p.done("cancelled")
+			return nil, err
+		}
+
+		d, err = ds.Get(d.ID)
+		if err != nil {
+			p.done("error")
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// RunDropletSnapshotRotate snapshots a droplet, or every droplet with a tag,
+// and deletes the oldest snapshots whose names match the name template.
+func RunDropletSnapshotRotate(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	keep, err := c.Doit.GetInt(c.NS, doctl.ArgKeep)
+	if err != nil {
+		return err
+	}
+	if keep < 1 {
+		return fmt.Errorf("%s must be at least 1", doctl.ArgKeep)
+	}
+
+	nameTemplate, err := c.Doit.GetString(c.NS, doctl.ArgNameTemplate)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid name template: %v", err)
+	}
+
+	ds := c.Droplets()
+
+	var droplets do.Droplets
+	if id, err := strconv.Atoi(c.Args[0]); err == nil {
+		d, err := ds.Get(id)
+		if err != nil {
+			return err
+		}
+		droplets = do.Droplets{*d}
+	} else {
+		droplets, err = ds.ListByTag(c.Args[0])
+		if err != nil {
+			return err
+		}
+		if len(droplets) == 0 {
+			return fmt.Errorf("no droplets are tagged %q", c.Args[0])
+		}
+	}
+
+	now := snapshotNow().UTC()
+	das := c.DropletActions()
+
+	var errs []string
+	var snapshotted do.Droplets
+	for _, d := range droplets {
+		name, err := snapshotName(tmpl, d, now)
+		if err != nil {
+			return err
+		}
+
+		a, err := das.Snapshot(d.ID, name)
+		if err == nil {
+			a, err = actionWait(c, a.ID, 5)
+		}
+		if err == nil && a.Status != "completed" {
+			err = fmt.Errorf("snapshot action %d %s", a.ID, a.Status)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("droplet %d: %v", d.ID, err))
+			continue
+		}
+
+		snapshotted = append(snapshotted, d)
+	}
+
+	var plan []*snapshotPlanItem
+	if len(snapshotted) > 0 {
+		images, err := c.Images().ListUser(false)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range snapshotted {
+			fresh, err := ds.Get(d.ID)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("droplet %d: %v", d.ID, err))
+				continue
+			}
+
+			items, err := rotatedSnapshots(tmpl, *fresh, images)
+			if err != nil {
+				return err
+			}
+
+			planSnapshotPrune(items, &snapshotPruneOptions{keepLast: keep}, now)
+			if err := executeSnapshotPlan(c.Images(), items); err != nil {
+				errs = append(errs, fmt.Sprintf("droplet %d: %v", d.ID, err))
+			}
+
+			plan = append(plan, items...)
+		}
+	}
+
+	if err := c.Display(&snapshotPlan{items: plan}); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("snapshot rotation failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// RunDropletSummary counts droplets by region, size and tag.
+func RunDropletSummary(c *CmdConfig) error {
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
+	}
+
+	ds := c.Droplets()
+
+	var list do.Droplets
+	if tagName == "" {
+		list, err = ds.List()
+	} else {
+		list, err = ds.ListByTag(tagName)
+	}
+	if err != nil {
+		return err
+	}
+
+	summary := &dropletFleet{
+		Total:   len(list),
+		Regions: map[string]int{},
+		Sizes:   map[string]int{},
+		Tags:    map[string]int{},
+	}
+	for _, d := range list {
+		if d.Region != nil {
+			summary.Regions[d.Region.Slug]++
+		}
+		summary.Sizes[d.SizeSlug]++
+		for _, t := range d.Tags {
+			summary.Tags[t]++
+		}
+	}
+
+	return c.Display(summary)
+}
+
+// sortedCounts returns the keys of counts, largest count first.
+func sortedCounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Sort(keysByCount{keys: keys, counts: counts})
+	return keys
+}
+
+type keysByCount struct {
+	keys   []string
+	counts map[string]int
+}
+
+func (k keysByCount) Len() int      { return len(k.keys) }
+func (k keysByCount) Swap(i, j int) { k.keys[i], k.keys[j] = k.keys[j], k.keys[i] }
+func (k keysByCount) Less(i, j int) bool {
+	ci, cj := k.counts[k.keys[i]], k.counts[k.keys[j]]
+	if ci != cj {
+		return ci > cj
+	}
+	return k.keys[i] < k.keys[j]
+}
@@ -14,9 +14,14 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
@@ -37,7 +42,7 @@ var (
 func TestDropletCommand(t *testing.T) {
 	cmd := Droplet()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "actions", "backups", "create", "delete", "get", "kernels", "list", "neighbors", "snapshots", "tag", "untag")
+	assertCommandNames(t, cmd, "actions", "backups", "console", "create", "delete", "get", "kernels", "list", "neighbors", "reverse-dns", "set-reverse-dns", "snapshots", "tag", "snapshot-rotate", "summary", "untag", "wait")
 }
 
 func TestDropletActionList(t *testing.T) {
@@ -51,6 +56,44 @@ func TestDropletActionList(t *testing.T) {
 	})
 }
 
+func TestDropletActionListFiltered(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		actions := do.Actions{
+			{Action: &godo.Action{ID: 1, Type: "resize", Status: "completed", CompletedAt: &godo.Timestamp{Time: time.Now()}}},
+			{Action: &godo.Action{ID: 2, Type: "resize", Status: "errored", CompletedAt: &godo.Timestamp{Time: time.Now()}}},
+			{Action: &godo.Action{ID: 3, Type: "power_off", Status: "errored", CompletedAt: &godo.Timestamp{Time: time.Now()}}},
+			{Action: &godo.Action{ID: 4, Type: "resize", Status: "errored"}},
+		}
+		tm.droplets.On("Actions", 1).Return(actions, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+		config.Doit.Set(config.NS, doctl.ArgActionType, "resize")
+		config.Doit.Set(config.NS, doctl.ArgActionStatus, "errored")
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletActions(config)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, "2", strings.Fields(lines[0])[0])
+		assert.Equal(t, "4", strings.Fields(lines[1])[0])
+	})
+}
+
+func TestDropletActionListError(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Actions", 1).Return(nil, fmt.Errorf("boom"))
+
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletActions(config)
+		assert.Error(t, err)
+	})
+}
+
 func TestDropletBackupList(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.droplets.On("Backups", 1).Return(testImageList, nil)
@@ -80,6 +123,7 @@ func TestDropletCreate(t *testing.T) {
 			PrivateNetworking: false,
 			UserData:          "#cloud-config",
 		}
+		tm.images.On("GetBySlug", "image").Return(&testImage, nil)
 		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
 
 		config.Args = append(config.Args, "droplet")
@@ -98,6 +142,7 @@ func TestDropletCreate(t *testing.T) {
 func TestDropletCreateWithTag(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{ID: 0, Slug: "image"}, SSHKeys: []godo.DropletCreateSSHKey{}, Backups: false, IPv6: false, PrivateNetworking: false, UserData: "#cloud-config"}
+		tm.images.On("GetBySlug", "image").Return(&testImage, nil)
 		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
 
 		trr := &godo.TagResourcesRequest{
@@ -123,6 +168,7 @@ func TestDropletCreateWithTag(t *testing.T) {
 func TestDropletCreateUserDataFile(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{ID: 0, Slug: "image"}, SSHKeys: []godo.DropletCreateSSHKey{}, Backups: false, IPv6: false, PrivateNetworking: false, UserData: "#cloud-config\n\ncoreos:\n  etcd2:\n    # generate a new token for each unique cluster from https://discovery.etcd.io/new?size=5\n    # specify the initial size of your cluster with ?size=X\n    discovery: https://discovery.etcd.io/<token>\n    # multi-region and multi-cloud deployments need to use $public_ipv4\n    advertise-client-urls: http://$private_ipv4:2379,http://$private_ipv4:4001\n    initial-advertise-peer-urls: http://$private_ipv4:2380\n    # listen on both the official ports and the legacy ports\n    # legacy ports can be omitted if your application doesn't depend on them\n    listen-client-urls: http://0.0.0.0:2379,http://0.0.0.0:4001\n    listen-peer-urls: http://$private_ipv4:2380\n  units:\n    - name: etcd2.service\n      command: start\n    - name: fleet.service\n      command: start\n"}
+		tm.images.On("GetBySlug", "image").Return(&testImage, nil)
 		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
 
 		config.Args = append(config.Args, "droplet")
@@ -137,6 +183,142 @@ func TestDropletCreateUserDataFile(t *testing.T) {
 	})
 }
 
+func TestDropletCreateMultipleDisplay(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.images.On("GetBySlug", "image").Return(&testImage, nil)
+
+		first := do.Droplet{Droplet: &godo.Droplet{ID: 10, Name: "web-1", Status: "active", Image: testImage.Image, Region: &godo.Region{Slug: "dev0"}, Networks: &godo.Networks{
+			V4: []godo.NetworkV4{{IPAddress: "10.0.0.10", Type: "public"}},
+		}}}
+		second := do.Droplet{Droplet: &godo.Droplet{ID: 11, Name: "web-2", Status: "active", Image: testImage.Image, Region: &godo.Region{Slug: "dev0"}, Networks: &godo.Networks{
+			V4: []godo.NetworkV4{{IPAddress: "10.0.0.11", Type: "public"}},
+		}}}
+		for _, d := range []do.Droplet{first, second} {
+			dcr := &godo.DropletCreateRequest{Name: d.Name, Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{Slug: "image"}, SSHKeys: []godo.DropletCreateSSHKey{}}
+			tm.droplets.On("Create", dcr, true).Return(&do.Droplet{Droplet: d.Droplet}, nil)
+		}
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "web-1", "web-2")
+
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "image")
+		config.Doit.Set(config.NS, doctl.ArgCommandWait, true)
+		config.Doit.Set(config.NS, doctl.ArgFormat, "PublicIPv4")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDropletCreate(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.10\n10.0.0.11\n", buf.String())
+	})
+}
+
+func TestDropletCreateFromSnapshotName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		snapshot := do.Image{Image: &godo.Image{ID: 42, Name: "golden"}}
+		tm.images.On("GetBySlug", "golden").Return(nil, errors.New("not found"))
+		tm.images.On("ListUser", false).Return(do.Images{testImage, snapshot}, nil)
+
+		dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{ID: 42}, SSHKeys: []godo.DropletCreateSSHKey{}}
+		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
+
+		config.Args = append(config.Args, "droplet")
+
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "golden")
+
+		err := RunDropletCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletCreateAmbiguousSnapshotName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Images{
+			{Image: &godo.Image{ID: 42, Name: "golden"}},
+			{Image: &godo.Image{ID: 43, Name: "golden"}},
+		}
+		tm.images.On("GetBySlug", "golden").Return(nil, errors.New("not found"))
+		tm.images.On("ListUser", false).Return(list, nil)
+
+		config.Args = append(config.Args, "droplet")
+
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "golden")
+
+		err := RunDropletCreate(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestDropletCreateImageListFails(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.images.On("GetBySlug", "custom").Return(nil, errors.New("not found"))
+		tm.images.On("ListUser", false).Return(nil, errors.New("unavailable"))
+
+		dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{Slug: "custom"}, SSHKeys: []godo.DropletCreateSSHKey{}}
+		tm.droplets.On("Create", dcr, false).Return(&testDroplet, nil)
+
+		config.Args = append(config.Args, "droplet")
+
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "custom")
+
+		err := RunDropletCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletConsole(t *testing.T) {
+	defer func(f func(string) error) { consoleBrowserOpen = f }(consoleBrowserOpen)
+
+	var opened string
+	consoleBrowserOpen = func(u string) error {
+		opened = u
+		return nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "another-droplet")
+
+		err := RunDropletConsole(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://cloud.digitalocean.com/droplets/3/console\n", buf.String())
+		assert.Equal(t, "https://cloud.digitalocean.com/droplets/3/console", opened)
+	})
+}
+
+func TestDropletConsoleNoBrowser(t *testing.T) {
+	defer func(f func(string) error) { consoleBrowserOpen = f }(consoleBrowserOpen)
+
+	consoleBrowserOpen = func(u string) error {
+		t.Fatalf("unexpected browser open of %s", u)
+		return nil
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "1")
+		config.Doit.Set(config.NS, doctl.ArgNoBrowser, true)
+
+		err := RunDropletConsole(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://cloud.digitalocean.com/droplets/1/console\n", buf.String())
+	})
+}
+
 func TestDropletDelete(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.droplets.On("Delete", 1).Return(nil)
@@ -211,6 +393,152 @@ func TestDropletDelete_MixedNameAndType(t *testing.T) {
 
 }
 
+func dropletWithStatus(id int, status string) *do.Droplet {
+	return &do.Droplet{Droplet: &godo.Droplet{ID: id, Status: status}}
+}
+
+// createdDroplet is testDroplet with status, complete enough to display.
+func createdDroplet(status string) *do.Droplet {
+	d := *testDroplet.Droplet
+	d.Status = status
+	return &do.Droplet{Droplet: &d}
+}
+
+func TestDropletCreateWaitPollsUntilActive(t *testing.T) {
+	defer func(d time.Duration) { dropletWaitInterval = d }(dropletWaitInterval)
+	dropletWaitInterval = time.Millisecond
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{Slug: "image"}, SSHKeys: []godo.DropletCreateSSHKey{}}
+		tm.images.On("GetBySlug", "image").Return(&testImage, nil)
+		tm.droplets.On("Create", dcr, true).Return(createdDroplet("new"), nil)
+		tm.droplets.On("Get", 1).Return(createdDroplet("new"), nil).Once()
+		tm.droplets.On("Get", 1).Return(createdDroplet("active"), nil).Once()
+
+		config.Args = append(config.Args, "droplet")
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "image")
+		config.Doit.Set(config.NS, doctl.ArgCommandWait, true)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 60)
+
+		err := RunDropletCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletCreateWaitFails(t *testing.T) {
+	defer func(d time.Duration) { dropletWaitInterval = d }(dropletWaitInterval)
+
+	cases := []struct {
+		status   string
+		interval time.Duration
+	}{
+		{"errored", time.Millisecond},
+		{"archive", time.Millisecond},
+		// an interval longer than the timeout gives up before polling.
+		{"new", 2 * time.Second},
+	}
+
+	for _, tc := range cases {
+		dropletWaitInterval = tc.interval
+
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			dcr := &godo.DropletCreateRequest{Name: "droplet", Region: "dev0", Size: "1gb", Image: godo.DropletCreateImage{Slug: "image"}, SSHKeys: []godo.DropletCreateSSHKey{}}
+			tm.images.On("GetBySlug", "image").Return(&testImage, nil)
+			tm.droplets.On("Create", dcr, true).Return(createdDroplet(tc.status), nil)
+
+			config.Args = append(config.Args, "droplet")
+			config.Doit.Set(config.NS, doctl.ArgRegionSlug, "dev0")
+			config.Doit.Set(config.NS, doctl.ArgSizeSlug, "1gb")
+			config.Doit.Set(config.NS, doctl.ArgImage, "image")
+			config.Doit.Set(config.NS, doctl.ArgCommandWait, true)
+			config.Doit.Set(config.NS, doctl.ArgTimeout, 1)
+
+			err := RunDropletCreate(config)
+			assert.Error(t, err, tc.status)
+		})
+	}
+}
+
+func TestDropletDeleteGraceful(t *testing.T) {
+	defer func(d time.Duration) { dropletWaitInterval = d }(dropletWaitInterval)
+	dropletWaitInterval = time.Millisecond
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(dropletWithStatus(1, "active"), nil).Twice()
+		tm.droplets.On("Get", 1).Return(dropletWithStatus(1, "off"), nil)
+		tm.dropletActions.On("Shutdown", 1).Return(&testAction, nil)
+		tm.droplets.On("Delete", 1).Return(nil)
+
+		config.Doit.Set(config.NS, doctl.ArgGraceful, true)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 60)
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletDelete(config)
+		assert.NoError(t, err)
+		tm.dropletActions.AssertNotCalled(t, "PowerOff", 1)
+		tm.droplets.AssertCalled(t, "Delete", 1)
+	})
+}
+
+func TestDropletDeleteGracefulPowerOff(t *testing.T) {
+	defer func(d time.Duration) { dropletWaitInterval = d }(dropletWaitInterval)
+	dropletWaitInterval = time.Millisecond
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(dropletWithStatus(1, "active"), nil).Twice()
+		tm.droplets.On("Get", 1).Return(dropletWithStatus(1, "off"), nil)
+		tm.dropletActions.On("Shutdown", 1).Return(&testAction, nil)
+		tm.dropletActions.On("PowerOff", 1).Return(&testAction, nil)
+		tm.droplets.On("Delete", 1).Return(nil)
+
+		// a zero timeout gives up on the shutdown after the first poll.
+		config.Doit.Set(config.NS, doctl.ArgGraceful, true)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 0)
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletDelete(config)
+		assert.NoError(t, err)
+		tm.dropletActions.AssertCalled(t, "PowerOff", 1)
+		tm.droplets.AssertCalled(t, "Delete", 1)
+	})
+}
+
+func TestDropletDeleteGracefulNotOff(t *testing.T) {
+	defer func(d time.Duration) { dropletWaitInterval = d }(dropletWaitInterval)
+	dropletWaitInterval = time.Millisecond
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(dropletWithStatus(1, "active"), nil)
+		tm.dropletActions.On("Shutdown", 1).Return(nil, assert.AnError)
+		tm.dropletActions.On("PowerOff", 1).Return(&testAction, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgGraceful, true)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 0)
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletDelete(config)
+		assert.Error(t, err)
+		tm.droplets.AssertNotCalled(t, "Delete", 1)
+	})
+}
+
+func TestDropletDeleteGracefulByTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("ListByTag", "my-tag").Return(do.Droplets{*dropletWithStatus(2, "off")}, nil)
+		tm.droplets.On("Get", 2).Return(dropletWithStatus(2, "off"), nil)
+		tm.droplets.On("DeleteByTag", "my-tag").Return(nil)
+
+		config.Doit.Set(config.NS, doctl.ArgGraceful, true)
+		config.Doit.Set(config.NS, doctl.ArgTagName, "my-tag")
+
+		err := RunDropletDelete(config)
+		assert.NoError(t, err)
+		tm.dropletActions.AssertNotCalled(t, "Shutdown", 2)
+	})
+}
+
 func TestDropletGet(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.droplets.On("Get", testDroplet.ID).Return(&testDroplet, nil)
@@ -222,6 +550,50 @@ func TestDropletGet(t *testing.T) {
 	})
 }
 
+func TestDropletGetMultiple(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+		tm.droplets.On("Get", 3).Return(&anotherTestDroplet, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "1", "3")
+		config.Doit.Set(config.NS, doctl.ArgFormat, "ID")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDropletGet(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "3"}, strings.Fields(buf.String()))
+	})
+}
+
+func TestDropletGetByName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "another-droplet", "1", "a-droplet")
+		config.Doit.Set(config.NS, doctl.ArgFormat, "ID")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDropletGet(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3", "1"}, strings.Fields(buf.String()))
+	})
+}
+
+func TestDropletGetByNameMissing(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+
+		config.Args = append(config.Args, "missing")
+
+		err := RunDropletGet(config)
+		assert.Error(t, err)
+	})
+}
+
 func TestDropletKernelList(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.droplets.On("Kernels", testDroplet.ID).Return(testKernelList, nil)
@@ -264,6 +636,57 @@ func TestDropletsList(t *testing.T) {
 	})
 }
 
+func TestDropletsListQuiet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(doctl.NSRoot, doctl.ArgQuiet, true)
+
+		err := RunDropletList(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "1\n3\n", buf.String())
+	})
+}
+
+func TestDropletsListFilters(t *testing.T) {
+	cases := []struct {
+		key, value string
+		expected   []string
+	}{
+		{key: doctl.ArgName, value: "a-droplet", expected: []string{"a-droplet"}},
+		{key: doctl.ArgNameRegex, value: "^an", expected: []string{"another-droplet"}},
+		{key: doctl.ArgNameRegex, value: "droplet$", expected: []string{"a-droplet", "another-droplet"}},
+		{key: doctl.ArgRegionSlug, value: "other0", expected: []string{}},
+	}
+
+	for _, c := range cases {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("List").Return(testDropletList, nil)
+
+			var buf bytes.Buffer
+			config.Out = &buf
+			config.Doit.Set(config.NS, c.key, c.value)
+			config.Doit.Set(config.NS, doctl.ArgFormat, "Name")
+			config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+			err := RunDropletList(config)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, strings.Fields(buf.String()))
+		})
+	}
+}
+
+func TestDropletsListInvalidRegex(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgNameRegex, "(")
+
+		err := RunDropletList(config)
+		assert.Error(t, err)
+	})
+}
+
 func TestDropletsListByTag(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		tm.droplets.On("ListByTag", "my-tag").Return(testDropletList, nil)
@@ -350,6 +773,44 @@ func TestDropletsTagMultipleNameAndID(t *testing.T) {
 }
 
 func TestDropletsUntag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		urr := &godo.UntagResourcesRequest{
+			Resources: []godo.Resource{
+				{ID: "1", Type: godo.DropletResourceType},
+			},
+		}
+
+		tm.tags.On("UntagResources", "my-tag", urr).Return(nil)
+		tm.droplets.On("List").Return(testDropletList, nil)
+
+		config.Args = append(config.Args, testDroplet.Name)
+		config.Doit.Set(config.NS, doctl.ArgTagName, "my-tag")
+
+		err := RunDropletUntag(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletsUntagByID(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		urr := &godo.UntagResourcesRequest{
+			Resources: []godo.Resource{
+				{ID: "1", Type: godo.DropletResourceType},
+				{ID: "3", Type: godo.DropletResourceType},
+			},
+		}
+
+		tm.tags.On("UntagResources", "my-tag", urr).Return(nil)
+
+		config.Args = append(config.Args, "1", "3")
+		config.Doit.Set(config.NS, doctl.ArgTagName, "my-tag")
+
+		err := RunDropletUntag(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletsUntagDeprecatedForm(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
 		urr := &godo.UntagResourcesRequest{
 			Resources: []godo.Resource{
@@ -368,6 +829,15 @@ func TestDropletsUntag(t *testing.T) {
 	})
 }
 
+func TestDropletsUntagMissingTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "1")
+
+		err := RunDropletUntag(config)
+		assert.Error(t, err)
+	})
+}
+
 func Test_extractSSHKey(t *testing.T) {
 	cases := []struct {
 		in       string
@@ -396,3 +866,135 @@ func Test_extractSSHKey(t *testing.T) {
 		assert.Equal(t, c.expected, got)
 	}
 }
+
+func TestDropletWait(t *testing.T) {
+	ogInterval := dropletWaitInterval
+	dropletWaitInterval = 0
+	defer func() { dropletWaitInterval = ogInterval }()
+
+	newDroplet := do.Droplet{Droplet: &godo.Droplet{ID: 1, Name: "a-droplet", Status: "new", Image: testDroplet.Image, Region: testDroplet.Region}}
+	activeDroplet := do.Droplet{Droplet: &godo.Droplet{ID: 1, Name: "a-droplet", Status: "active", Image: testDroplet.Image, Region: testDroplet.Region}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&newDroplet, nil).Once()
+		tm.droplets.On("Get", 1).Return(&activeDroplet, nil).Once()
+
+		config.Args = append(config.Args, "1")
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "active")
+
+		err := RunDropletWait(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletWaitCancelled(t *testing.T) {
+	newDroplet := do.Droplet{Droplet: &godo.Droplet{ID: 1, Name: "a-droplet", Status: "new", Image: testDroplet.Image, Region: testDroplet.Region}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&newDroplet, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		config.Context = ctx
+		config.Args = append(config.Args, "1")
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "active")
+
+		err := RunDropletWait(config)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestDropletWaitByName(t *testing.T) {
+	off := do.Droplet{Droplet: &godo.Droplet{ID: 3, Name: "another-droplet", Status: "off", Image: testDroplet.Image, Region: testDroplet.Region}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(do.Droplets{testDroplet, off}, nil)
+
+		config.Args = append(config.Args, "another-droplet")
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "off")
+
+		err := RunDropletWait(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDropletWaitTimeout(t *testing.T) {
+	ogInterval := dropletWaitInterval
+	dropletWaitInterval = time.Hour
+	defer func() { dropletWaitInterval = ogInterval }()
+
+	newDroplet := do.Droplet{Droplet: &godo.Droplet{ID: 1, Status: "new"}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&newDroplet, nil)
+
+		config.Args = append(config.Args, "1")
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "active")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 60)
+
+		err := RunDropletWait(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+}
+
+func TestDropletWaitInvalidStatus(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "1")
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "running")
+
+		err := RunDropletWait(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestDropletsListWide(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(do.Droplets{testDroplet}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(doctl.NSRoot, doctl.ArgOutput, "wide")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDropletList(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "a-droplet", "8.8.8.8", "172.16.1.2", "0", "0", "0", "test0", "DOOS", "an-image", "0"},
+			strings.Fields(buf.String()))
+	})
+}
+
+func TestDropletSummary(t *testing.T) {
+	droplets := do.Droplets{
+		{Droplet: &godo.Droplet{ID: 1, Region: &godo.Region{Slug: "nyc1"}, SizeSlug: "1gb", Tags: []string{"web", "prod"}}},
+		{Droplet: &godo.Droplet{ID: 2, Region: &godo.Region{Slug: "nyc1"}, SizeSlug: "512mb", Tags: []string{"web"}}},
+		{Droplet: &godo.Droplet{ID: 3, Region: &godo.Region{Slug: "sfo2"}, SizeSlug: "1gb"}},
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("ListByTag", "web").Return(droplets, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunDropletSummary(config)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		expected := [][]string{
+			{"total", "3"},
+			{"region", "nyc1", "2"},
+			{"region", "sfo2", "1"},
+			{"size", "1gb", "2"},
+			{"size", "512mb", "1"},
+			{"tag", "web", "2"},
+			{"tag", "prod", "1"},
+		}
+		assert.Len(t, lines, len(expected))
+		for i, e := range expected {
+			assert.Equal(t, e, strings.Fields(lines[i]))
+		}
+	})
+}
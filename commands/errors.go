@@ -18,14 +18,15 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/digitalocean/doctl/do"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	colorErr  = color.New(color.FgRed).SprintFunc()("Error")
-	colorWarn = color.New(color.FgYellow).SprintFunc()("Warning")
+	colorErr  = color.New(color.FgRed).SprintFunc()
+	colorWarn = color.New(color.FgYellow).SprintFunc()
 
 	// errAction specifies what should happen when an error occurs
 	errAction = func() {
@@ -53,7 +54,10 @@ func checkErr(err error, cmd ...*cobra.Command) {
 		if len(cmd) > 0 {
 			cmd[0].Help()
 		}
-		fmt.Fprintf(color.Output, "\n%s: %v\n", colorErr, err)
+		fmt.Fprintf(color.Output, "\n%s: %v\n", colorErr("Error"), err)
+		if do.IsUnauthorized(err) {
+			fmt.Fprintf(color.Output, "Run \"doctl auth login\" to authenticate with a valid access token.\n")
+		}
 	case "json":
 		es := outputErrors{
 			Errors: []outputError{
@@ -69,5 +73,5 @@ func checkErr(err error, cmd ...*cobra.Command) {
 }
 
 func warn(msg string) {
-	fmt.Fprintf(color.Output, "%s: %s\n", colorWarn, msg)
+	fmt.Fprintf(color.Output, "%s: %s\n", colorWarn("Warning"), msg)
 }
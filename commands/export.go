@@ -0,0 +1,578 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	tfResourceTypes = []string{
+		"droplet", "domain", "record", "ssh_key", "floating_ip", "volume", "tag",
+	}
+
+	tfInvalidNameRE = regexp.MustCompile("[^A-Za-z0-9_-]")
+
+	stateResourceTypes = []string{"volume", "droplet", "domain"}
+)
+
+// Export creates the export commands heirarchy.
+func Export() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "export",
+			Short: "export commands",
+			Long:  "export is used to export existing resources to other formats",
+		},
+	}
+
+	cmdExportTerraform := CmdBuilder(cmd, RunExportTerraform, "terraform", "export resources as terraform configuration", Writer,
+		aliasOpt("tf"))
+	AddStringSliceFlag(cmdExportTerraform, doctl.ArgExportResources, []string{},
+		fmt.Sprintf("Resource types to export. Possible values: %s", strings.Join(tfResourceTypes, ",")))
+	AddStringFlag(cmdExportTerraform, doctl.ArgExportImportFile, "", "Write the terraform import commands to this file instead of a comment after the configuration")
+
+	cmdExportState := CmdBuilder(cmd, RunExportState, "state", "export resources as a manifest usable by apply", Writer)
+	AddStringSliceFlag(cmdExportState, doctl.ArgExportResources, []string{},
+		fmt.Sprintf("Resource types to export. Possible values: %s", strings.Join(stateResourceTypes, ",")))
+
+	return cmd
+}
+
+// RunExportState exports existing resources as a single yaml or json
+// document. The document can be used as a manifest for apply and diff.
+func RunExportState(c *CmdConfig) error {
+	rawTypes, err := c.Doit.GetStringSlice(c.NS, doctl.ArgExportResources)
+	if err != nil {
+		return err
+	}
+
+	types, err := extractResourceTypes(rawTypes, stateResourceTypes)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.Doit.GetString(doctl.NSRoot, doctl.ArgOutput)
+	if err != nil {
+		return err
+	}
+
+	m, err := collectState(c, types)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		return writeJSON(m, c.Out)
+	case "", "text", "yaml":
+		b, err := yaml.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Out.Write(b)
+		return err
+	default:
+		return fmt.Errorf("unknown output type")
+	}
+}
+
+func collectState(c *CmdConfig, types []string) (*manifest, error) {
+	m := &manifest{}
+
+	// volumes are listed for droplets too, so they can refer to them by name.
+	var volumes []do.Volume
+	volumeNames := map[string]string{}
+	for _, t := range types {
+		if t != "volume" && t != "droplet" {
+			continue
+		}
+
+		list, err := c.Volumes().List()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range list {
+			volumeNames[v.ID] = v.Name
+		}
+		volumes = list
+		break
+	}
+
+	for _, t := range types {
+		switch t {
+		case "volume":
+			for _, v := range volumes {
+				mv := manifestVolume{
+					Name:          v.Name,
+					SizeGigaBytes: v.SizeGigaBytes,
+					Description:   v.Description,
+				}
+				if v.Region != nil {
+					mv.Region = v.Region.Slug
+				}
+
+				m.Volumes = append(m.Volumes, mv)
+			}
+
+		case "droplet":
+			list, err := c.Droplets().List()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range list {
+				md := manifestDroplet{
+					Name: d.Name,
+					Size: d.SizeSlug,
+					Tags: d.Tags,
+				}
+
+				if d.Region != nil {
+					md.Region = d.Region.Slug
+				}
+
+				if d.Image != nil {
+					md.Image = d.Image.Slug
+					if md.Image == "" {
+						md.Image = strconv.Itoa(d.Image.ID)
+					}
+				}
+
+				if d.Networks != nil {
+					md.IPv6 = len(d.Networks.V6) > 0
+					for _, n := range d.Networks.V4 {
+						if n.Type == string(do.InterfacePrivate) {
+							md.PrivateNetworking = true
+						}
+					}
+				}
+
+				for _, id := range d.VolumeIDs {
+					if name, ok := volumeNames[id]; ok {
+						id = name
+					}
+					md.Volumes = append(md.Volumes, id)
+				}
+
+				m.Droplets = append(m.Droplets, md)
+			}
+
+		case "domain":
+			ds := c.Domains()
+			list, err := ds.List()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range list {
+				records, err := ds.Records(d.Name)
+				if err != nil {
+					return nil, err
+				}
+
+				md := manifestDomain{Name: d.Name}
+				for _, r := range records {
+					if r.Type == "SOA" {
+						continue
+					}
+
+					md.Records = append(md.Records, liveRecord(r))
+				}
+
+				m.Domains = append(m.Domains, md)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// RunExportTerraform exports existing resources as terraform configuration,
+// followed by the terraform import commands that bring them under
+// terraform's management. The import commands are written as a comment,
+// or to their own file when one is given.
+func RunExportTerraform(c *CmdConfig) error {
+	rawTypes, err := c.Doit.GetStringSlice(c.NS, doctl.ArgExportResources)
+	if err != nil {
+		return err
+	}
+
+	types, err := extractResourceTypes(rawTypes, tfResourceTypes)
+	if err != nil {
+		return err
+	}
+
+	importFile, err := c.Doit.GetString(c.NS, doctl.ArgExportImportFile)
+	if err != nil {
+		return err
+	}
+
+	tf := newTFExport()
+	for _, t := range types {
+		if err := tf.collect(c, t); err != nil {
+			return err
+		}
+	}
+
+	out := tf.config()
+	if importFile != "" {
+		if err := ioutil.WriteFile(importFile, tf.importCommands(), 0755); err != nil {
+			return err
+		}
+	} else {
+		out = append(out, tf.importComment()...)
+	}
+
+	_, err = c.Out.Write(out)
+	return err
+}
+
+// extractResourceTypes normalizes a resource type list. An empty list selects
+// all the known types.
+func extractResourceTypes(in []string, known []string) ([]string, error) {
+	var types []string
+	for _, raw := range in {
+		raw = strings.TrimPrefix(raw, "[")
+		raw = strings.TrimSuffix(raw, "]")
+
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.Replace(strings.TrimSpace(t), "-", "_", -1)
+			if t != "" {
+				types = append(types, t)
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return known, nil
+	}
+
+	for _, t := range types {
+		found := false
+		for _, k := range known {
+			if t == k {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown resource type %q", t)
+		}
+	}
+
+	return types, nil
+}
+
+type tfAttr struct {
+	key   string
+	value interface{}
+}
+
+type tfResource struct {
+	kind     string
+	name     string
+	importID string
+	attrs    []tfAttr
+}
+
+func (r *tfResource) importCommand() string {
+	return fmt.Sprintf("terraform import %s.%s %s", r.kind, r.name, r.importID)
+}
+
+type tfExport struct {
+	resources []tfResource
+	names     map[string]int
+}
+
+func newTFExport() *tfExport {
+	return &tfExport{names: map[string]int{}}
+}
+
+// add registers a resource, making sure its terraform name is unique for its kind.
+func (tf *tfExport) add(kind, name, importID string, attrs ...tfAttr) {
+	name = tfInvalidNameRE.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "r_" + name
+	}
+
+	key := kind + "." + name
+	tf.names[key]++
+	if n := tf.names[key]; n > 1 {
+		name = fmt.Sprintf("%s_%d", name, n)
+	}
+
+	tf.resources = append(tf.resources, tfResource{
+		kind:     kind,
+		name:     name,
+		importID: importID,
+		attrs:    attrs,
+	})
+}
+
+func (tf *tfExport) collect(c *CmdConfig, resourceType string) error {
+	switch resourceType {
+	case "droplet":
+		list, err := c.Droplets().List()
+		if err != nil {
+			return err
+		}
+
+		for _, d := range list {
+			attrs := []tfAttr{{"name", d.Name}}
+			if d.Region != nil {
+				attrs = append(attrs, tfAttr{"region", d.Region.Slug})
+			}
+			attrs = append(attrs, tfAttr{"size", d.SizeSlug})
+
+			if d.Image != nil {
+				if d.Image.Slug != "" {
+					attrs = append(attrs, tfAttr{"image", d.Image.Slug})
+				} else {
+					attrs = append(attrs, tfAttr{"image", strconv.Itoa(d.Image.ID)})
+				}
+			}
+
+			if d.Networks != nil {
+				if len(d.Networks.V6) > 0 {
+					attrs = append(attrs, tfAttr{"ipv6", true})
+				}
+
+				for _, n := range d.Networks.V4 {
+					if n.Type == string(do.InterfacePrivate) {
+						attrs = append(attrs, tfAttr{"private_networking", true})
+						break
+					}
+				}
+			}
+
+			if len(d.Tags) > 0 {
+				attrs = append(attrs, tfAttr{"tags", d.Tags})
+			}
+
+			if len(d.VolumeIDs) > 0 {
+				attrs = append(attrs, tfAttr{"volume_ids", d.VolumeIDs})
+			}
+
+			tf.add("digitalocean_droplet", d.Name, strconv.Itoa(d.ID), attrs...)
+		}
+
+	case "domain":
+		list, err := c.Domains().List()
+		if err != nil {
+			return err
+		}
+
+		for _, d := range list {
+			tf.add("digitalocean_domain", d.Name, d.Name,
+				tfAttr{"name", d.Name})
+		}
+
+	case "record":
+		ds := c.Domains()
+		domains, err := ds.List()
+		if err != nil {
+			return err
+		}
+
+		for _, d := range domains {
+			records, err := ds.Records(d.Name)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range records {
+				// SOA and NS records are managed by DigitalOcean.
+				if r.Type == "SOA" || r.Type == "NS" {
+					continue
+				}
+
+				attrs := []tfAttr{
+					{"domain", d.Name},
+					{"type", r.Type},
+					{"name", r.Name},
+					{"value", r.Data},
+				}
+
+				if r.Priority != 0 {
+					attrs = append(attrs, tfAttr{"priority", r.Priority})
+				}
+				if r.Port != 0 {
+					attrs = append(attrs, tfAttr{"port", r.Port})
+				}
+				if r.Weight != 0 {
+					attrs = append(attrs, tfAttr{"weight", r.Weight})
+				}
+
+				name := fmt.Sprintf("%s_%s_%s", d.Name, strings.ToLower(r.Type), r.Name)
+				importID := fmt.Sprintf("%s,%d", d.Name, r.ID)
+				tf.add("digitalocean_record", name, importID, attrs...)
+			}
+		}
+
+	case "ssh_key":
+		list, err := c.Keys().List()
+		if err != nil {
+			return err
+		}
+
+		for _, k := range list {
+			tf.add("digitalocean_ssh_key", k.Name, strconv.Itoa(k.ID),
+				tfAttr{"name", k.Name},
+				tfAttr{"public_key", strings.TrimSpace(k.PublicKey)})
+		}
+
+	case "floating_ip":
+		list, err := c.FloatingIPs().List()
+		if err != nil {
+			return err
+		}
+
+		for _, f := range list {
+			attrs := []tfAttr{}
+			if f.Region != nil {
+				attrs = append(attrs, tfAttr{"region", f.Region.Slug})
+			}
+			if f.Droplet != nil {
+				attrs = append(attrs, tfAttr{"droplet_id", f.Droplet.ID})
+			}
+
+			tf.add("digitalocean_floating_ip", f.IP, f.IP, attrs...)
+		}
+
+	case "volume":
+		list, err := c.Volumes().List()
+		if err != nil {
+			return err
+		}
+
+		for _, v := range list {
+			attrs := []tfAttr{{"name", v.Name}}
+			if v.Region != nil {
+				attrs = append(attrs, tfAttr{"region", v.Region.Slug})
+			}
+			attrs = append(attrs, tfAttr{"size", int(v.SizeGigaBytes)})
+			if v.Description != "" {
+				attrs = append(attrs, tfAttr{"description", v.Description})
+			}
+
+			tf.add("digitalocean_volume", v.Name, v.ID, attrs...)
+		}
+
+	case "tag":
+		list, err := c.Tags().List()
+		if err != nil {
+			return err
+		}
+
+		for _, t := range list {
+			tf.add("digitalocean_tag", t.Name, t.Name,
+				tfAttr{"name", t.Name})
+		}
+	}
+
+	return nil
+}
+
+// config renders the collected resources as HCL.
+func (tf *tfExport) config() []byte {
+	var buf bytes.Buffer
+
+	for i, r := range tf.resources {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buf, "resource %q %q {\n", r.kind, r.name)
+
+		width := 0
+		for _, a := range r.attrs {
+			if len(a.key) > width {
+				width = len(a.key)
+			}
+		}
+
+		for _, a := range r.attrs {
+			fmt.Fprintf(&buf, "  %-*s = %s\n", width, a.key, tfValue(a.value))
+		}
+
+		buf.WriteString("}\n")
+	}
+
+	return buf.Bytes()
+}
+
+// importCommands renders the terraform import commands for the collected
+// resources as a shell script.
+func (tf *tfExport) importCommands() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("#!/bin/sh\nset -e\n\n")
+	for _, r := range tf.resources {
+		fmt.Fprintf(&buf, "%s\n", r.importCommand())
+	}
+
+	return buf.Bytes()
+}
+
+// importComment renders the terraform import commands for the collected
+// resources as an HCL comment, to follow the configuration.
+func (tf *tfExport) importComment() []byte {
+	if len(tf.resources) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\n# Bring the resources above under terraform's management with:\n#\n")
+	for _, r := range tf.resources {
+		fmt.Fprintf(&buf, "#   %s\n", r.importCommand())
+	}
+
+	return buf.Bytes()
+}
+
+func tfValue(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return tfQuote(t)
+	case []string:
+		quoted := make([]string, len(t))
+		for i := range t {
+			quoted[i] = tfQuote(t[i])
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// tfQuote quotes s as an HCL string, escaping the interpolation and
+// template sequences so terraform reads s literally.
+func tfQuote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.Replace(s, "${", "$${", -1)
+	return strings.Replace(s, "%{", "%%{", -1)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCommand(t *testing.T) {
+	cmd := Export()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "state", "terraform")
+}
+
+func TestExportTerraform(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		records := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "www", Data: "8.8.8.8"}},
+		}
+
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.domains.On("List").Return(testDomainList, nil)
+		tm.domains.On("Records", "example.com").Return(records, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"droplet,record"})
+
+		err := RunExportTerraform(config)
+		assert.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, `resource "digitalocean_droplet" "a-droplet" {`)
+		assert.Contains(t, out, `resource "digitalocean_droplet" "another-droplet" {`)
+		assert.Contains(t, out, `private_networking = true`)
+		assert.Contains(t, out, `resource "digitalocean_record" "example_com_a_www" {`)
+		assert.Contains(t, out, `value  = "8.8.8.8"`)
+		assert.NotContains(t, out, "ns1.digitalocean.com")
+	})
+}
+
+func TestExportTerraformMissingRegion(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(do.Droplets{{Droplet: &godo.Droplet{ID: 3, Name: "no-region", SizeSlug: "1gb"}}}, nil)
+		tm.floatingIPs.On("List").Return(do.FloatingIPs{{FloatingIP: &godo.FloatingIP{IP: "10.0.0.9"}}}, nil)
+		tm.volumes.On("List").Return([]do.Volume{{Volume: &godo.Volume{ID: "abc", Name: "data", SizeGigaBytes: 10}}}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"droplet,floating_ip,volume"})
+
+		err := RunExportTerraform(config)
+		assert.NoError(t, err)
+
+		out := buf.String()
+		assert.Contains(t, out, `resource "digitalocean_droplet" "no-region" {`)
+		assert.Contains(t, out, `resource "digitalocean_volume" "data" {`)
+		assert.Contains(t, out, `resource "digitalocean_floating_ip"`)
+		assert.NotRegexp(t, `region\s+=`, out)
+	})
+}
+
+func TestExportTerraformImportCommands(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testDomainList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"domain"})
+
+		err := RunExportTerraform(config)
+		assert.NoError(t, err)
+
+		expected := `resource "digitalocean_domain" "example_com" {
+  name = "example.com"
+}
+
+# Bring the resources above under terraform's management with:
+#
+#   terraform import digitalocean_domain.example_com example.com
+`
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestExportTerraformImportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctl-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	importFile := filepath.Join(dir, "import.sh")
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testDomainList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"domain"})
+		config.Doit.Set(config.NS, doctl.ArgExportImportFile, importFile)
+
+		err := RunExportTerraform(config)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "terraform import")
+
+		b, err := ioutil.ReadFile(importFile)
+		require.NoError(t, err)
+		assert.Equal(t, "#!/bin/sh\nset -e\n\nterraform import digitalocean_domain.example_com example.com\n", string(b))
+	})
+}
+
+func TestTFValueEscapesTemplates(t *testing.T) {
+	assert.Equal(t, `"v=spf1 $${a} %%{b} $$${c}"`, tfValue("v=spf1 ${a} %{b} $${c}"))
+	assert.Equal(t, `["$${x}"]`, tfValue([]string{"${x}"}))
+}
+
+func TestExportTerraformUnknownResource(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"load_balancer"})
+
+		err := RunExportTerraform(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestExportState(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		attached := do.Droplet{Droplet: &godo.Droplet{
+			ID:        5,
+			Name:      "attached",
+			SizeSlug:  "1gb",
+			Region:    &godo.Region{Slug: "atlantis"},
+			Image:     &godo.Image{Slug: "ubuntu"},
+			VolumeIDs: []string{testVolume.ID},
+		}}
+
+		records := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "www", Data: "8.8.8.8"}},
+		}
+
+		tm.volumes.On("List").Return([]do.Volume{testVolume}, nil)
+		tm.droplets.On("List").Return(do.Droplets{attached}, nil)
+		tm.domains.On("List").Return(testDomainList, nil)
+		tm.domains.On("Records", "example.com").Return(records, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+
+		err := RunExportState(config)
+		assert.NoError(t, err)
+
+		expected := `volumes:
+- name: test-volume
+  region: atlantis
+  size_gigabytes: 100
+  description: test description
+droplets:
+- name: attached
+  region: atlantis
+  size: 1gb
+  image: ubuntu
+  volumes:
+  - test-volume
+domains:
+- name: example.com
+  records:
+  - type: A
+    name: www
+    data: 8.8.8.8
+`
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestExportStateDomainsOnly(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("List").Return(testDomainList, nil)
+		tm.domains.On("Records", "example.com").Return(do.DomainRecords{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgExportResources, []string{"domain"})
+
+		err := RunExportState(config)
+		assert.NoError(t, err)
+		tm.volumes.AssertNotCalled(t, "List")
+		assert.Equal(t, "domains:\n- name: example.com\n", buf.String())
+	})
+}
@@ -50,6 +50,9 @@ func FloatingIP() *Command {
 
 	CmdBuilder(cmd, RunFloatingIPDelete, "delete <floating-ip>", "delete a floating IP address", Writer, aliasOpt("d"))
 
+	CmdBuilder(cmd, RunFloatingIPReverseDNS, "reverse-dns <floating-ip>", "show the reverse DNS of a floating IP", Writer,
+		aliasOpt("ptr"), displayerType(&reverseDNS{}), docCategories("floatingip"))
+
 	cmdFloatingIPList := CmdBuilder(cmd, RunFloatingIPList, "list", "list all floating IP addresses", Writer,
 		aliasOpt("ls"), displayerType(&floatingIP{}), docCategories("floatingip"))
 	AddStringFlag(cmdFloatingIPList, doctl.ArgRegionSlug, "", "Floating IP region")
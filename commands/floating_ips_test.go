@@ -24,7 +24,7 @@ import (
 func TestFloatingIPCommands(t *testing.T) {
 	cmd := FloatingIP()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "create", "delete", "get", "list")
+	assertCommandNames(t, cmd, "create", "delete", "get", "list", "reverse-dns")
 }
 
 func TestFloatingIPsList(t *testing.T) {
@@ -0,0 +1,75 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import "time"
+
+// PreRunHook is called before a command runs. Returning an error aborts the
+// command, and the error is reported as the command's error.
+type PreRunHook func(c *CmdConfig) error
+
+// PostRunHook is called after a command runs with the time the command took
+// and the error it returned, if any.
+type PostRunHook func(c *CmdConfig, elapsed time.Duration, err error)
+
+var (
+	preRunHooks  []PreRunHook
+	postRunHooks []PostRunHook
+)
+
+// AddPreRunHook registers a hook which runs before every command.
+func AddPreRunHook(h PreRunHook) {
+	preRunHooks = append(preRunHooks, h)
+}
+
+// AddPostRunHook registers a hook which runs after every command.
+func AddPostRunHook(h PostRunHook) {
+	postRunHooks = append(postRunHooks, h)
+}
+
+// AddPreRunHook registers a hook which runs before this command. Global hooks
+// run first.
+func (c *Command) AddPreRunHook(h PreRunHook) {
+	c.preRunHooks = append(c.preRunHooks, h)
+}
+
+// AddPostRunHook registers a hook which runs after this command. Global hooks
+// run first.
+func (c *Command) AddPostRunHook(h PostRunHook) {
+	c.postRunHooks = append(c.postRunHooks, h)
+}
+
+// runWithHooks runs cr surrounded by the global and command hooks. Post-run
+// hooks are skipped if a pre-run hook aborts the command.
+func (c *Command) runWithHooks(config *CmdConfig, cr CmdRunner) error {
+	for _, hooks := range [][]PreRunHook{preRunHooks, c.preRunHooks} {
+		for _, h := range hooks {
+			if err := h(config); err != nil {
+				return err
+			}
+		}
+	}
+
+	start := time.Now()
+	err := cr(config)
+	elapsed := time.Since(start)
+
+	for _, hooks := range [][]PostRunHook{postRunHooks, c.postRunHooks} {
+		for _, h := range hooks {
+			h(config, elapsed, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetHooks() {
+	preRunHooks = nil
+	postRunHooks = nil
+}
+
+func TestRunWithHooks(t *testing.T) {
+	defer resetHooks()
+
+	var calls []string
+	AddPreRunHook(func(c *CmdConfig) error {
+		calls = append(calls, "global-pre")
+		return nil
+	})
+	AddPostRunHook(func(c *CmdConfig, elapsed time.Duration, err error) {
+		calls = append(calls, "global-post")
+	})
+
+	cmd := &Command{}
+	cmd.AddPreRunHook(func(c *CmdConfig) error {
+		calls = append(calls, "cmd-pre")
+		return nil
+	})
+
+	boom := errors.New("boom")
+	var postErr error
+	cmd.AddPostRunHook(func(c *CmdConfig, elapsed time.Duration, err error) {
+		calls = append(calls, "cmd-post")
+		postErr = err
+	})
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		err := cmd.runWithHooks(config, func(c *CmdConfig) error {
+			calls = append(calls, "run")
+			return boom
+		})
+		assert.Equal(t, boom, err)
+	})
+
+	assert.Equal(t, []string{"global-pre", "cmd-pre", "run", "global-post", "cmd-post"}, calls)
+	assert.Equal(t, boom, postErr)
+}
+
+func TestRunWithHooksPreRunAborts(t *testing.T) {
+	defer resetHooks()
+
+	denied := errors.New("denied")
+	AddPreRunHook(func(c *CmdConfig) error {
+		return denied
+	})
+
+	posted := false
+	AddPostRunHook(func(c *CmdConfig, elapsed time.Duration, err error) {
+		posted = true
+	})
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		ran := false
+		err := (&Command{}).runWithHooks(config, func(c *CmdConfig) error {
+			ran = true
+			return nil
+		})
+		assert.Equal(t, denied, err)
+		assert.False(t, ran)
+	})
+
+	assert.False(t, posted)
+}
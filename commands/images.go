@@ -15,6 +15,7 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/digitalocean/doctl"
@@ -64,6 +65,10 @@ func Images() *Command {
 	CmdBuilder(cmd, RunImagesDelete, "delete <image-id>", "Delete image", Writer,
 		docCategories("image"))
 
+	cmdImageActions := CmdBuilder(cmd, RunImageActions, "actions <image-id>", "List image actions", Writer,
+		aliasOpt("a"), displayerType(&action{}), docCategories("image"))
+	addActionFilterFlags(cmdImageActions)
+
 	return cmd
 }
 
@@ -214,3 +219,38 @@ func RunImagesDelete(c *CmdConfig) error {
 
 	return is.Delete(id)
 }
+
+// RunImageActions lists the actions run against an image. The API has no
+// per-image action listing, so the account's actions are narrowed down to
+// the ones for this image.
+func RunImageActions(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	id, err := strconv.Atoi(c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	all, err := c.Actions().List()
+	if err != nil {
+		return err
+	}
+
+	list := do.Actions{}
+	for _, a := range all {
+		if a.ResourceType == "image" && a.ResourceID == id {
+			list = append(list, a)
+		}
+	}
+
+	list, err = filterActionList(c, list)
+	if err != nil {
+		return err
+	}
+
+	sort.Stable(actionsByCompletedAt(list))
+
+	return c.Display(&action{actions: list})
+}
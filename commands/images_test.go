@@ -14,10 +14,13 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,7 +28,7 @@ import (
 func TestImageCommand(t *testing.T) {
 	cmd := Images()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "delete", "get", "list", "list-application", "list-distribution", "list-user", "update")
+	assertCommandNames(t, cmd, "actions", "delete", "get", "list", "list-application", "list-distribution", "list-user", "update")
 }
 
 func TestImagesList(t *testing.T) {
@@ -114,3 +117,33 @@ func TestImagesDelete(t *testing.T) {
 	})
 
 }
+
+func TestImageActions(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		actions := do.Actions{
+			{Action: &godo.Action{ID: 1, ResourceType: "image", ResourceID: 7, Type: "transfer"}},
+			{Action: &godo.Action{ID: 2, ResourceType: "droplet", ResourceID: 7, Type: "resize"}},
+			{Action: &godo.Action{ID: 3, ResourceType: "image", ResourceID: 8, Type: "transfer"}},
+		}
+		tm.actions.On("List").Return(actions, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+		config.Args = append(config.Args, "7")
+
+		err := RunImageActions(config)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 1)
+		assert.Equal(t, "1", strings.Fields(lines[0])[0])
+	})
+}
+
+func TestImageActionsMissingID(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		err := RunImageActions(config)
+		assert.Error(t, err)
+	})
+}
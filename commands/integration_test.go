@@ -0,0 +1,133 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/do/fakeapi"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Integration tests run commands against the fake API in do/fakeapi. They
+// are slower than the mock based tests, so they only run when asked:
+//
+//	go test ./commands -run-integration
+var runIntegration = flag.Bool("run-integration", false, "run end to end tests against a fake API server")
+
+func withFakeAPI(t *testing.T, fn func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer)) {
+	if !*runIntegration {
+		t.Skip("integration tests run with -run-integration")
+	}
+
+	ogConfig := doctl.DoitConfig
+	defer func() {
+		doctl.DoitConfig = ogConfig
+	}()
+
+	cfg := NewTestConfig()
+	doctl.DoitConfig = cfg
+
+	s := fakeapi.NewServer()
+	defer s.Close()
+
+	var out bytes.Buffer
+	config := NewCmdConfigWithClient("test", cfg, &out, []string{}, s.Client())
+
+	fn(s, config, &out)
+}
+
+func TestIntegrationDropletListPaginates(t *testing.T) {
+	withFakeAPI(t, func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer) {
+		s.MaxPerPage = 1
+
+		err := RunDropletList(config)
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), "web-1")
+		assert.Contains(t, out.String(), "db-1")
+	})
+}
+
+func TestIntegrationDropletCreateWait(t *testing.T) {
+	withFakeAPI(t, func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer) {
+		config.Args = []string{"app-1"}
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "sfo2")
+		config.Doit.Set(config.NS, doctl.ArgSizeSlug, "512mb")
+		config.Doit.Set(config.NS, doctl.ArgImage, "ubuntu-16-04-x64")
+		config.Doit.Set(config.NS, doctl.ArgCommandWait, true)
+
+		err := RunDropletCreate(config)
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), "app-1")
+		assert.Contains(t, out.String(), "active")
+		assert.Equal(t, 3, s.Len("droplets"))
+	})
+}
+
+func TestIntegrationDropletWait(t *testing.T) {
+	ogInterval := dropletWaitInterval
+	dropletWaitInterval = 0
+	defer func() { dropletWaitInterval = ogInterval }()
+
+	withFakeAPI(t, func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer) {
+		s.BootPolls = 3
+		s.Add("droplets", &godo.Droplet{
+			ID: 900, Name: "booting", Status: "new",
+			Region: &godo.Region{Slug: "nyc1"},
+			Image:  &godo.Image{ID: 100, Slug: "ubuntu-16-04-x64"},
+		})
+
+		config.Args = []string{"booting"}
+		config.Doit.Set(config.NS, doctl.ArgDropletStatus, "active")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5)
+
+		err := RunDropletWait(config)
+		require.NoError(t, err)
+	})
+}
+
+func TestIntegrationActionWait(t *testing.T) {
+	withFakeAPI(t, func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer) {
+		s.ActionPolls = 1
+
+		a, err := config.DropletActions().PowerOff(300)
+		require.NoError(t, err)
+
+		a, err = actionWait(config, a.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "completed", a.Status)
+	})
+}
+
+func TestIntegrationRateLimited(t *testing.T) {
+	withFakeAPI(t, func(s *fakeapi.Server, config *CmdConfig, out *bytes.Buffer) {
+		s.FailNext(1, http.StatusTooManyRequests, "API Rate limit exceeded.")
+
+		err := RunAccountGet(config)
+		assert.True(t, do.IsRateLimited(err))
+
+		err = RunAccountGet(config)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "sammy@example.com")
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+)
+
+// exitInterrupted is the exit code used when doctl is stopped by a signal.
+const exitInterrupted = 130
+
+// inFlight tracks server side work doctl is waiting on, so it can be
+// reported if doctl is interrupted.
+var inFlight = newPendingWork()
+
+type pendingWork struct {
+	mu    sync.Mutex
+	next  int
+	items map[int]string
+}
+
+func newPendingWork() *pendingWork {
+	return &pendingWork{items: map[int]string{}}
+}
+
+// track records work as in flight until the returned func is called. Each
+// call is tracked separately, even if its description is the same as
+// another's.
+func (p *pendingWork) track(format string, a ...interface{}) func() {
+	desc := fmt.Sprintf(format, a...)
+
+	p.mu.Lock()
+	p.next++
+	token := p.next
+	p.items[token] = desc
+	p.mu.Unlock()
+
+	return func() {
+		p.mu.Lock()
+		delete(p.items, token)
+		p.mu.Unlock()
+	}
+}
+
+func (p *pendingWork) report(w io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.items) == 0 {
+		fmt.Fprintln(w, "interrupted")
+		return
+	}
+
+	var items []string
+	for _, desc := range p.items {
+		items = append(items, desc)
+	}
+	sort.Strings(items)
+
+	fmt.Fprintln(w, "interrupted, still running on DigitalOcean:")
+	for _, desc := range items {
+		fmt.Fprintf(w, "  %s\n", desc)
+	}
+}
+
+// handleInterrupts cancels cmdContext on SIGINT or SIGTERM, reporting the
+// work that continues server side. The running command stops waiting and
+// returns, so its post-run hooks still run, then doctl exits with
+// exitInterrupted. A second signal exits straight away.
+func handleInterrupts() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmdContext = ctx
+
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-sigs
+		inFlight.report(os.Stderr)
+		cancel()
+
+		<-sigs
+		os.Exit(exitInterrupted)
+	}()
+}
+
+// exitIfInterrupted exits with exitInterrupted if the command was stopped
+// by a signal.
+func exitIfInterrupted() {
+	if cmdContext.Err() != nil {
+		os.Exit(exitInterrupted)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingWorkReport(t *testing.T) {
+	p := newPendingWork()
+
+	var buf bytes.Buffer
+	p.report(&buf)
+	assert.Equal(t, "interrupted\n", buf.String())
+
+	doneB := p.track("action %d", 2)
+	doneA := p.track("action %d", 1)
+	done := p.track("creating droplet %s", "web")
+	done()
+
+	buf.Reset()
+	p.report(&buf)
+	assert.Equal(t, "interrupted, still running on DigitalOcean:\n  action 1\n  action 2\n", buf.String())
+
+	doneA()
+	doneB()
+	assert.Empty(t, p.items)
+}
+
+func TestPendingWorkSameDescription(t *testing.T) {
+	p := newPendingWork()
+
+	first := p.track("creating droplet %s", "web")
+	p.track("creating droplet %s", "web")
+	first()
+
+	var buf bytes.Buffer
+	p.report(&buf)
+	assert.Equal(t, "interrupted, still running on DigitalOcean:\n  creating droplet web\n", buf.String())
+}
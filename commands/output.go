@@ -19,8 +19,10 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
 )
 
 var (
@@ -186,6 +188,180 @@ func (d *domain) KV() []map[string]interface{} {
 	return out
 }
 
+type domainZone struct {
+	domains do.Domains
+}
+
+var _ Displayable = &domainZone{}
+
+func (d *domainZone) JSON(out io.Writer) error {
+	return writeJSON(d.domains, out)
+}
+
+func (d *domainZone) Cols() []string {
+	return []string{"Domain", "TTL", "SOA", "Nameservers"}
+}
+
+func (d *domainZone) ColMap() map[string]string {
+	return map[string]string{
+		"Domain": "Domain", "TTL": "TTL", "SOA": "SOA", "Nameservers": "Nameservers",
+	}
+}
+
+func (d *domainZone) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, do := range d.domains {
+		soa, ns := parseZoneFile(do.Name, do.ZoneFile)
+		o := map[string]interface{}{
+			"Domain": do.Name, "TTL": do.TTL, "SOA": soa,
+			"Nameservers": strings.Join(ns, ","),
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
+
+// parseZoneFile extracts the SOA data and apex nameservers from a zone file.
+func parseZoneFile(name, zone string) (string, []string) {
+	var soa string
+	var ns []string
+	for _, line := range strings.Split(zone, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		for i, f := range fields {
+			switch f {
+			case "SOA":
+				soa = strings.Join(fields[i+1:], " ")
+			case "NS":
+				owner := fields[0]
+				if i+1 < len(fields) && (owner == "@" || owner == name+".") {
+					ns = append(ns, fields[i+1])
+				}
+			default:
+				continue
+			}
+			break
+		}
+	}
+
+	return soa, ns
+}
+
+type domainCreateResult struct {
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type domainCreateResults struct {
+	results []domainCreateResult
+}
+
+var _ Displayable = &domainCreateResults{}
+
+func (d *domainCreateResults) JSON(out io.Writer) error {
+	return writeJSON(d.results, out)
+}
+
+func (d *domainCreateResults) Cols() []string {
+	return []string{"Domain", "Status", "Error"}
+}
+
+func (d *domainCreateResults) ColMap() map[string]string {
+	return map[string]string{
+		"Domain": "Domain", "Status": "Status", "Error": "Error",
+	}
+}
+
+func (d *domainCreateResults) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, r := range d.results {
+		o := map[string]interface{}{
+			"Domain": r.Domain, "Status": r.Status, "Error": r.Error,
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type recordImport struct {
+	records []importedRecord
+}
+
+var _ Displayable = &recordImport{}
+
+func (ri *recordImport) JSON(out io.Writer) error {
+	return writeJSON(ri.records, out)
+}
+
+func (ri *recordImport) Cols() []string {
+	return []string{"Type", "Name", "Data", "Status", "Note"}
+}
+
+func (ri *recordImport) ColMap() map[string]string {
+	return map[string]string{
+		"Type": "Type", "Name": "Name", "Data": "Data", "Status": "Status", "Note": "Note",
+	}
+}
+
+func (ri *recordImport) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, r := range ri.records {
+		o := map[string]interface{}{
+			"Type": r.Type, "Name": r.Name, "Data": r.Data, "Status": r.Status, "Note": r.Note,
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type nameserverStatus struct {
+	Nameserver string `json:"nameserver"`
+	Status     string `json:"status"`
+}
+
+type nameserver struct {
+	nameservers []nameserverStatus
+}
+
+var _ Displayable = &nameserver{}
+
+func (n *nameserver) JSON(out io.Writer) error {
+	return writeJSON(n.nameservers, out)
+}
+
+func (n *nameserver) Cols() []string {
+	return []string{"Nameserver", "Status"}
+}
+
+func (n *nameserver) ColMap() map[string]string {
+	return map[string]string{
+		"Nameserver": "Nameserver", "Status": "Status",
+	}
+}
+
+func (n *nameserver) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, ns := range n.nameservers {
+		o := map[string]interface{}{
+			"Nameserver": ns.Nameserver, "Status": ns.Status,
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
+
 type domainRecord struct {
 	domainRecords do.DomainRecords
 }
@@ -242,12 +418,19 @@ func (d *droplet) Cols() []string {
 	return cols
 }
 
+func (d *droplet) WideCols() []string {
+	return []string{
+		"ID", "Name", "PublicIPv4", "PrivateIPv4", "Memory", "VCPUs", "Disk", "Region", "Size",
+		"Image", "Status", "Tags", "Volumes", "Backups", "Created",
+	}
+}
+
 func (d *droplet) ColMap() map[string]string {
 	return map[string]string{
-		"ID": "ID", "Name": "Name", "PublicIPv4": "Public IPv4",
+		"ID": "ID", "Name": "Name", "PublicIPv4": "Public IPv4", "PrivateIPv4": "Private IPv4",
 		"Memory": "Memory", "VCPUs": "VCPUs", "Disk": "Disk",
-		"Region": "Region", "Image": "Image", "Status": "Status",
-		"Tags": "Tags", "Volumes": "Volumes",
+		"Region": "Region", "Size": "Size", "Image": "Image", "Status": "Status",
+		"Tags": "Tags", "Volumes": "Volumes", "Backups": "Backups", "Created": "Created",
 	}
 }
 
@@ -257,12 +440,13 @@ func (d *droplet) KV() []map[string]interface{} {
 		tags := strings.Join(d.Tags, ",")
 		image := fmt.Sprintf("%s %s", d.Image.Distribution, d.Image.Name)
 		ip, _ := d.PublicIPv4()
+		privateIP, _ := d.PrivateIPv4()
 		volumes := strings.Join(d.VolumeIDs, ",")
 		m := map[string]interface{}{
-			"ID": d.ID, "Name": d.Name, "PublicIPv4": ip,
+			"ID": d.ID, "Name": d.Name, "PublicIPv4": ip, "PrivateIPv4": privateIP,
 			"Memory": d.Memory, "VCPUs": d.Vcpus, "Disk": d.Disk,
-			"Region": d.Region.Slug, "Image": image, "Status": d.Status,
-			"Tags": tags, "Volumes": volumes,
+			"Region": d.Region.Slug, "Size": d.SizeSlug, "Image": image, "Status": d.Status,
+			"Tags": tags, "Volumes": volumes, "Backups": len(d.BackupIDs), "Created": d.Created,
 		}
 		out = append(out, m)
 	}
@@ -621,3 +805,350 @@ func (a *volume) KV() []map[string]interface{} {
 	return out
 
 }
+
+type snapshotPlan struct {
+	items []*snapshotPlanItem
+}
+
+var _ Displayable = &snapshotPlan{}
+
+func (sp *snapshotPlan) JSON(out io.Writer) error {
+	return writeJSON(sp.items, out)
+}
+
+func (sp *snapshotPlan) Cols() []string {
+	return []string{"ID", "Name", "Droplet", "Created", "Status"}
+}
+
+func (sp *snapshotPlan) ColMap() map[string]string {
+	return map[string]string{
+		"ID": "ID", "Name": "Name", "Droplet": "Droplet", "Created": "Created At", "Status": "Status",
+	}
+}
+
+func (sp *snapshotPlan) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, i := range sp.items {
+		created := ""
+		if !i.Created.IsZero() {
+			created = i.Created.Format(time.RFC3339)
+		}
+
+		o := map[string]interface{}{
+			"ID": i.ID, "Name": i.Name, "Droplet": i.DropletName, "Created": created, "Status": i.Status,
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type searchResults struct {
+	results []searchResult
+}
+
+var _ Displayable = &searchResults{}
+
+func (sr *searchResults) JSON(out io.Writer) error {
+	return writeJSON(sr.results, out)
+}
+
+func (sr *searchResults) Cols() []string {
+	return []string{"URN", "Type", "Name", "Match"}
+}
+
+func (sr *searchResults) ColMap() map[string]string {
+	return map[string]string{
+		"Type": "Type", "Name": "Name", "URN": "URN", "Match": "Match",
+	}
+}
+
+func (sr *searchResults) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, r := range sr.results {
+		o := map[string]interface{}{
+			"Type": r.Type, "Name": r.Name, "URN": r.URN, "Match": r.Match,
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type orphans struct {
+	orphans []orphan
+}
+
+var _ Displayable = &orphans{}
+
+func (o *orphans) JSON(out io.Writer) error {
+	return writeJSON(o.orphans, out)
+}
+
+func (o *orphans) Cols() []string {
+	return []string{"URN", "Type", "Name", "Reason", "MonthlyCost"}
+}
+
+func (o *orphans) ColMap() map[string]string {
+	return map[string]string{
+		"URN": "URN", "Type": "Type", "Name": "Name", "Reason": "Reason",
+		"MonthlyCost": "Est. Monthly Cost",
+	}
+}
+
+func (o *orphans) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, r := range o.orphans {
+		m := map[string]interface{}{
+			"URN": r.URN, "Type": r.Type, "Name": r.Name, "Reason": r.Reason,
+			"MonthlyCost": formatCost(r.MonthlyCost, r.CostIsApprox),
+		}
+
+		out = append(out, m)
+	}
+
+	return out
+}
+
+type costEstimate struct {
+	lines []costLine
+}
+
+var _ Displayable = &costEstimate{}
+
+func (ce *costEstimate) JSON(out io.Writer) error {
+	return writeJSON(ce.lines, out)
+}
+
+func (ce *costEstimate) Cols() []string {
+	return []string{"Item", "Quantity", "PriceHourly", "PriceMonthly"}
+}
+
+func (ce *costEstimate) ColMap() map[string]string {
+	return map[string]string{
+		"Item": "Item", "Quantity": "Quantity",
+		"PriceHourly": "Price Hourly", "PriceMonthly": "Price Monthly",
+	}
+}
+
+func (ce *costEstimate) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, l := range ce.lines {
+		quantity := ""
+		if l.Quantity > 0 {
+			quantity = strconv.Itoa(l.Quantity)
+		}
+
+		o := map[string]interface{}{
+			"Item": l.Item, "Quantity": quantity,
+			"PriceHourly":  fmt.Sprintf("%0.5f", l.Hourly),
+			"PriceMonthly": fmt.Sprintf("%0.2f", l.Monthly),
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type accountSummary struct {
+	counts []resourceCount
+}
+
+var _ Displayable = &accountSummary{}
+
+func (as *accountSummary) JSON(out io.Writer) error {
+	return writeJSON(as.counts, out)
+}
+
+func (as *accountSummary) Cols() []string {
+	return []string{"Resource", "Count", "Limit"}
+}
+
+func (as *accountSummary) ColMap() map[string]string {
+	return map[string]string{
+		"Resource": "Resource", "Count": "Count", "Limit": "Limit",
+	}
+}
+
+func (as *accountSummary) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, rc := range as.counts {
+		limit := ""
+		if rc.Limit > 0 {
+			limit = strconv.Itoa(rc.Limit)
+		}
+
+		o := map[string]interface{}{
+			"Resource": rc.Resource, "Count": rc.Count, "Limit": limit,
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type aliasList []alias
+
+var _ Displayable = &aliasList{}
+
+func (al *aliasList) JSON(out io.Writer) error {
+	return writeJSON(al, out)
+}
+
+func (al *aliasList) Cols() []string {
+	return []string{"Name", "Command"}
+}
+
+func (al *aliasList) ColMap() map[string]string {
+	return map[string]string{
+		"Name": "Name", "Command": "Command",
+	}
+}
+
+func (al *aliasList) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, a := range *al {
+		o := map[string]interface{}{
+			"Name": a.Name, "Command": a.Command,
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type dropletFleet struct {
+	Total   int            `json:"total"`
+	Regions map[string]int `json:"regions"`
+	Sizes   map[string]int `json:"sizes"`
+	Tags    map[string]int `json:"tags"`
+}
+
+var _ Displayable = &dropletFleet{}
+
+func (ds *dropletFleet) JSON(out io.Writer) error {
+	return writeJSON(ds, out)
+}
+
+func (ds *dropletFleet) Cols() []string {
+	return []string{"Group", "Value", "Droplets"}
+}
+
+func (ds *dropletFleet) ColMap() map[string]string {
+	return map[string]string{
+		"Group": "Group", "Value": "Value", "Droplets": "Droplets",
+	}
+}
+
+func (ds *dropletFleet) KV() []map[string]interface{} {
+	out := []map[string]interface{}{
+		{"Group": "total", "Value": "", "Droplets": ds.Total},
+	}
+
+	groups := []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"region", ds.Regions}, {"size", ds.Sizes}, {"tag", ds.Tags},
+	}
+	for _, g := range groups {
+		for _, v := range sortedCounts(g.counts) {
+			o := map[string]interface{}{
+				"Group": g.name, "Value": v, "Droplets": g.counts[v],
+			}
+
+			out = append(out, o)
+		}
+	}
+
+	return out
+}
+
+type recordHistory []dnsJournalEntry
+
+var _ Displayable = &recordHistory{}
+
+func (rh *recordHistory) JSON(out io.Writer) error {
+	return writeJSON(rh, out)
+}
+
+func (rh *recordHistory) Cols() []string {
+	return []string{"Time", "Op", "Type", "Name", "Before", "After", "Actor"}
+}
+
+func (rh *recordHistory) ColMap() map[string]string {
+	return map[string]string{
+		"Time": "Time", "Op": "Op", "Type": "Type", "Name": "Name",
+		"Before": "Before", "After": "After", "Actor": "Actor",
+	}
+}
+
+func (rh *recordHistory) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, e := range *rh {
+		o := map[string]interface{}{
+			"Time": e.Time.Format(time.RFC3339), "Op": e.Op, "Actor": e.Actor,
+			"Before": "", "After": "",
+		}
+
+		for _, r := range []*godo.DomainRecord{e.After, e.Before} {
+			if r != nil {
+				o["Type"], o["Name"] = r.Type, r.Name
+			}
+		}
+		if e.Before != nil {
+			o["Before"] = e.Before.Data
+		}
+		if e.After != nil {
+			o["After"] = e.After.Data
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
+
+type reverseDNS []reverseDNSCheck
+
+var _ Displayable = &reverseDNS{}
+
+func (rd *reverseDNS) JSON(out io.Writer) error {
+	return writeJSON(rd, out)
+}
+
+func (rd *reverseDNS) Cols() []string {
+	return []string{"IP", "PTR", "Forward", "Match"}
+}
+
+func (rd *reverseDNS) ColMap() map[string]string {
+	return map[string]string{
+		"IP": "IP", "PTR": "PTR", "Forward": "Forward", "Match": "Match",
+	}
+}
+
+func (rd *reverseDNS) KV() []map[string]interface{} {
+	out := []map[string]interface{}{}
+
+	for _, r := range *rd {
+		o := map[string]interface{}{
+			"IP": r.IP, "PTR": r.PTR, "Forward": strings.Join(r.Forward, ","), "Match": r.Match,
+		}
+
+		out = append(out, o)
+	}
+
+	return out
+}
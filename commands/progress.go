@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/viper"
+)
+
+const (
+	progressNone = "none"
+	progressText = "text"
+	progressJSON = "json"
+)
+
+var (
+	// progressOut is where progress is reported.
+	progressOut io.Writer = os.Stderr
+
+	// progressIsTerminal reports whether progressOut is a terminal. Progress
+	// is only shown by default when it is.
+	progressIsTerminal = func() bool {
+		return isatty.IsTerminal(os.Stderr.Fd())
+	}
+
+	progressMu sync.Mutex
+)
+
+// progressEvent is emitted for each change in a task when progress is json.
+type progressEvent struct {
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"`
+	Operation string    `json:"operation"`
+	Status    string    `json:"status,omitempty"`
+	ElapsedMS int64     `json:"elapsed_ms"`
+}
+
+// progressTask reports the progress of a long running operation.
+type progressTask struct {
+	mode  string
+	op    string
+	start time.Time
+}
+
+// validateProgress checks the progress mode before a command runs.
+func validateProgress(c *CmdConfig) error {
+	switch mode := viper.GetString(doctl.ArgProgress); mode {
+	case "", progressNone, progressText, progressJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown progress mode %q", mode)
+	}
+}
+
+func progressMode() string {
+	mode := viper.GetString(doctl.ArgProgress)
+	if mode == "" {
+		if progressIsTerminal() {
+			return progressText
+		}
+		return progressNone
+	}
+
+	return mode
+}
+
+// startProgress starts reporting progress for an operation.
+func startProgress(format string, a ...interface{}) *progressTask {
+	t := &progressTask{
+		mode:  progressMode(),
+		op:    fmt.Sprintf(format, a...),
+		start: time.Now(),
+	}
+	t.emit("start", "")
+
+	return t
+}
+
+// update reports the current status of the operation.
+func (t *progressTask) update(status string) {
+	t.emit("update", status)
+}
+
+// done reports the final status of the operation.
+func (t *progressTask) done(status string) {
+	t.emit("done", status)
+}
+
+func (t *progressTask) emit(event, status string) {
+	elapsed := time.Since(t.start)
+
+	progressMu.Lock()
+	defer progressMu.Unlock()
+
+	switch t.mode {
+	case progressJSON:
+		b, err := json.Marshal(&progressEvent{
+			Time:      time.Now().UTC(),
+			Event:     event,
+			Operation: t.op,
+			Status:    status,
+			ElapsedMS: int64(elapsed / time.Millisecond),
+		})
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(progressOut, string(b))
+	case progressText:
+		elapsed -= elapsed % time.Second
+
+		switch event {
+		case "start":
+			fmt.Fprintf(progressOut, "%s: waiting\n", t.op)
+		case "update":
+			fmt.Fprintf(progressOut, "%s: %s (%s)\n", t.op, status, elapsed)
+		case "done":
+			fmt.Fprintf(progressOut, "%s: %s after %s\n", t.op, status, elapsed)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func withProgress(mode string, fn func(out *bytes.Buffer)) {
+	ogOut := progressOut
+	var buf bytes.Buffer
+	progressOut = &buf
+	viper.Set(doctl.ArgProgress, mode)
+	defer func() {
+		progressOut = ogOut
+		viper.Set(doctl.ArgProgress, "")
+	}()
+
+	fn(&buf)
+}
+
+func TestActionWaitProgressJSON(t *testing.T) {
+	inProgress := &do.Action{Action: &godo.Action{ID: 1, Status: "in-progress"}}
+	completed := &do.Action{Action: &godo.Action{ID: 1, Status: "completed"}}
+
+	withProgress(progressJSON, func(out *bytes.Buffer) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.actions.On("Get", 1).Return(inProgress, nil).Once()
+			tm.actions.On("Get", 1).Return(completed, nil).Once()
+
+			a, err := actionWait(config, 1, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, "completed", a.Status)
+		})
+
+		var events []progressEvent
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var e progressEvent
+			assert.NoError(t, json.Unmarshal([]byte(line), &e))
+			events = append(events, e)
+		}
+
+		assert.Len(t, events, 3)
+		for i, want := range []struct{ event, status string }{
+			{"start", ""},
+			{"update", "in-progress"},
+			{"done", "completed"},
+		} {
+			assert.Equal(t, "action 1", events[i].Operation)
+			assert.Equal(t, want.event, events[i].Event)
+			assert.Equal(t, want.status, events[i].Status)
+		}
+	})
+}
+
+func TestProgressText(t *testing.T) {
+	withProgress(progressText, func(out *bytes.Buffer) {
+		p := startProgress("action %d", 2)
+		p.update("in-progress")
+		p.done("completed")
+
+		assert.Equal(t, "action 2: waiting\naction 2: in-progress (0s)\naction 2: completed after 0s\n", out.String())
+	})
+}
+
+func TestProgressDefault(t *testing.T) {
+	ogIsTerminal := progressIsTerminal
+	defer func() { progressIsTerminal = ogIsTerminal }()
+
+	withProgress("", func(out *bytes.Buffer) {
+		progressIsTerminal = func() bool { return false }
+		startProgress("quiet").done("completed")
+		assert.Equal(t, "", out.String())
+
+		progressIsTerminal = func() bool { return true }
+		startProgress("shown").done("completed")
+		assert.Contains(t, out.String(), "shown: completed")
+	})
+}
+
+func TestValidateProgress(t *testing.T) {
+	withProgress("xml", func(out *bytes.Buffer) {
+		assert.Error(t, validateProgress(nil))
+	})
+	withProgress(progressJSON, func(out *bytes.Buffer) {
+		assert.NoError(t, validateProgress(nil))
+	})
+}
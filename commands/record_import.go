@@ -0,0 +1,311 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/godo"
+)
+
+// importedRecord is a record translated from a foreign export, or the
+// reason it could not be.
+type importedRecord struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Data   string `json:"data"`
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+
+	req *godo.DomainRecordEditRequest
+}
+
+type route53Export struct {
+	ResourceRecordSets []struct {
+		Name            string `json:"Name"`
+		Type            string `json:"Type"`
+		ResourceRecords []struct {
+			Value string `json:"Value"`
+		} `json:"ResourceRecords"`
+		AliasTarget *struct {
+			DNSName string `json:"DNSName"`
+		} `json:"AliasTarget"`
+	} `json:"ResourceRecordSets"`
+}
+
+type cloudflareExport struct {
+	Result []struct {
+		Type     string `json:"type"`
+		Name     string `json:"name"`
+		Content  string `json:"content"`
+		Proxied  bool   `json:"proxied"`
+		Priority int    `json:"priority"`
+	} `json:"result"`
+}
+
+// RunRecordImport creates records translated from a route53 or cloudflare
+// export.
+func RunRecordImport(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	domainName := c.Args[0]
+
+	provider, err := c.Doit.GetString(c.NS, doctl.ArgProvider)
+	if err != nil {
+		return err
+	}
+
+	path, err := c.Doit.GetString(c.NS, doctl.ArgFile)
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var records []importedRecord
+	switch provider {
+	case "route53":
+		records, err = translateRoute53(domainName, b)
+	case "cloudflare":
+		records, err = translateCloudflare(domainName, b)
+	default:
+		err = fmt.Errorf("unknown provider %q, expected route53 or cloudflare", provider)
+	}
+	if err != nil {
+		return err
+	}
+
+	ds := c.Domains()
+
+	failed := 0
+	for i := range records {
+		r := &records[i]
+		if r.req == nil {
+			continue
+		}
+
+		if _, err := ds.CreateRecord(domainName, r.req); err != nil {
+			r.Status = "failed"
+			r.Note = err.Error()
+			failed++
+			continue
+		}
+		r.Status = "created"
+	}
+
+	err = c.Display(&recordImport{records: records})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d records", failed)
+	}
+
+	return nil
+}
+
+func translateRoute53(domainName string, b []byte) ([]importedRecord, error) {
+	var export route53Export
+	if err := json.Unmarshal(b, &export); err != nil {
+		return nil, fmt.Errorf("unable to parse route53 export: %v", err)
+	}
+
+	var out []importedRecord
+	for _, rrs := range export.ResourceRecordSets {
+		name, inZone := relativeRecordName(domainName, rrs.Name)
+
+		if !inZone {
+			if rrs.AliasTarget != nil {
+				out = append(out, outOfZoneRecord(domainName, rrs.Type, name, rrs.AliasTarget.DNSName))
+			}
+			for _, rr := range rrs.ResourceRecords {
+				out = append(out, outOfZoneRecord(domainName, rrs.Type, name, rr.Value))
+			}
+			continue
+		}
+
+		if rrs.AliasTarget != nil {
+			r := importedRecord{Type: rrs.Type, Name: name, Data: rrs.AliasTarget.DNSName}
+			if name == "@" {
+				r.Status, r.Note = "skipped", "alias records at the apex have no equivalent"
+			} else {
+				r.Type = "CNAME"
+				r.Note = "alias imported as CNAME"
+				r.req = &godo.DomainRecordEditRequest{Type: "CNAME", Name: name, Data: fqdnData(rrs.AliasTarget.DNSName)}
+			}
+			out = append(out, r)
+			continue
+		}
+
+		for _, rr := range rrs.ResourceRecords {
+			out = append(out, translateRecord(rrs.Type, name, rr.Value))
+		}
+	}
+
+	return out, nil
+}
+
+func translateCloudflare(domainName string, b []byte) ([]importedRecord, error) {
+	var export cloudflareExport
+	if err := json.Unmarshal(b, &export); err != nil {
+		return nil, fmt.Errorf("unable to parse cloudflare export: %v", err)
+	}
+
+	var out []importedRecord
+	for _, cr := range export.Result {
+		name, inZone := relativeRecordName(domainName, cr.Name)
+		if !inZone {
+			out = append(out, outOfZoneRecord(domainName, cr.Type, name, cr.Content))
+			continue
+		}
+
+		data := cr.Content
+		switch strings.ToUpper(cr.Type) {
+		case "MX", "SRV":
+			data = fmt.Sprintf("%d %s", cr.Priority, cr.Content)
+		}
+
+		r := translateRecord(cr.Type, name, data)
+		if cr.Proxied && r.req != nil {
+			r.Note = "proxying is not available, record points at the origin"
+		}
+		out = append(out, r)
+	}
+
+	return out, nil
+}
+
+// translateRecord maps a record in zone file presentation format to a
+// record edit request.
+func translateRecord(rType, name, value string) importedRecord {
+	rType = strings.ToUpper(rType)
+	r := importedRecord{Type: rType, Name: name, Data: value}
+
+	req := &godo.DomainRecordEditRequest{Type: rType, Name: name}
+	switch rType {
+	case "A", "AAAA":
+		req.Data = value
+	case "CNAME":
+		req.Data = fqdnData(value)
+	case "NS":
+		if name == "@" {
+			r.Status, r.Note = "skipped", "apex nameservers are managed by DigitalOcean"
+			return r
+		}
+		req.Data = fqdnData(value)
+	case "TXT":
+		req.Data = unquoteTXT(value)
+	case "MX":
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			r.Status, r.Note = "skipped", "malformed MX value"
+			return r
+		}
+		p, err := strconv.Atoi(fields[0])
+		if err != nil {
+			r.Status, r.Note = "skipped", "malformed MX priority"
+			return r
+		}
+		req.Priority = p
+		req.Data = fqdnData(fields[1])
+	case "SRV":
+		fields := strings.Fields(value)
+		if len(fields) != 4 {
+			r.Status, r.Note = "skipped", "malformed SRV value"
+			return r
+		}
+		var nums [3]int
+		for i := range nums {
+			n, err := strconv.Atoi(fields[i])
+			if err != nil {
+				r.Status, r.Note = "skipped", "malformed SRV value"
+				return r
+			}
+			nums[i] = n
+		}
+		req.Priority, req.Weight, req.Port = nums[0], nums[1], nums[2]
+		req.Data = fqdnData(fields[3])
+	case "SOA":
+		r.Status, r.Note = "skipped", "SOA records are managed by DigitalOcean"
+		return r
+	default:
+		r.Status, r.Note = "skipped", fmt.Sprintf("%s records are not supported", rType)
+		return r
+	}
+
+	r.Data = req.Data
+	r.req = req
+	return r
+}
+
+// relativeRecordName converts a fully qualified name into a name relative
+// to domainName, using @ for the apex. Names outside domainName are returned
+// fully qualified with false.
+func relativeRecordName(domainName, name string) (string, bool) {
+	domainName = strings.TrimSuffix(strings.ToLower(domainName), ".")
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	// route53 escapes the wildcard label.
+	name = strings.Replace(name, `\052`, "*", -1)
+
+	if name == domainName {
+		return "@", true
+	}
+
+	if !strings.HasSuffix(name, "."+domainName) {
+		return name, false
+	}
+
+	return strings.TrimSuffix(name, "."+domainName), true
+}
+
+// outOfZoneRecord reports a record whose name is not part of domainName.
+func outOfZoneRecord(domainName, rType, name, value string) importedRecord {
+	return importedRecord{
+		Type:   strings.ToUpper(rType),
+		Name:   name,
+		Data:   value,
+		Status: "skipped",
+		Note:   fmt.Sprintf("name is outside %s", domainName),
+	}
+}
+
+func fqdnData(s string) string {
+	if strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+func unquoteTXT(s string) string {
+	if len(s) < 2 || s[0] != '"' {
+		return s
+	}
+
+	// long TXT values are split into several quoted strings.
+	return strings.Replace(s[1:len(s)-1], `" "`, "", -1)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestExport(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "export")
+	assert.NoError(t, err)
+
+	_, err = f.WriteString(content)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	return f.Name()
+}
+
+func TestRecordImportRoute53(t *testing.T) {
+	path := writeTestExport(t, `{"ResourceRecordSets": [
+		{"Name": "example.com.", "Type": "SOA", "ResourceRecords": [{"Value": "ns-1.awsdns-00.com. awsdns-hostmaster.amazon.com. 1 7200 900 1209600 86400"}]},
+		{"Name": "example.com.", "Type": "NS", "ResourceRecords": [{"Value": "ns-1.awsdns-00.com."}]},
+		{"Name": "example.com.", "Type": "A", "AliasTarget": {"DNSName": "lb.elb.amazonaws.com."}},
+		{"Name": "example.com.", "Type": "MX", "ResourceRecords": [{"Value": "10 mail.example.com."}, {"Value": "20 backup.example.com."}]},
+		{"Name": "example.com.", "Type": "TXT", "ResourceRecords": [{"Value": "\"v=spf1 \" \"-all\""}]},
+		{"Name": "www.example.com.", "Type": "A", "ResourceRecords": [{"Value": "10.0.0.1"}]},
+		{"Name": "cdn.example.com.", "Type": "A", "AliasTarget": {"DNSName": "d1.cloudfront.net."}},
+		{"Name": "\\052.example.com.", "Type": "CNAME", "ResourceRecords": [{"Value": "www.example.com"}]},
+		{"Name": "_sip._tcp.example.com.", "Type": "SRV", "ResourceRecords": [{"Value": "10 5 5060 sip.example.com."}]},
+		{"Name": "example.com.", "Type": "CAA", "ResourceRecords": [{"Value": "0 issue \"letsencrypt.org\""}]},
+		{"Name": "www.other.com.", "Type": "A", "ResourceRecords": [{"Value": "10.0.0.2"}]},
+		{"Name": "notexample.com.", "Type": "A", "AliasTarget": {"DNSName": "lb.elb.amazonaws.com."}}
+	]}`)
+	defer os.Remove(path)
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		reqs := []*godo.DomainRecordEditRequest{
+			{Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10},
+			{Type: "MX", Name: "@", Data: "backup.example.com.", Priority: 20},
+			{Type: "TXT", Name: "@", Data: "v=spf1 -all"},
+			{Type: "A", Name: "www", Data: "10.0.0.1"},
+			{Type: "CNAME", Name: "cdn", Data: "d1.cloudfront.net."},
+			{Type: "CNAME", Name: "*", Data: "www.example.com."},
+			{Type: "SRV", Name: "_sip._tcp", Data: "sip.example.com.", Priority: 10, Weight: 5, Port: 5060},
+		}
+		for _, req := range reqs {
+			tm.domains.On("CreateRecord", "example.com", req).Return(&testRecord, nil)
+		}
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgProvider, "route53")
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+		config.Doit.Set(config.NS, doctl.ArgFormat, "Type,Name,Status")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunRecordImport(config)
+		assert.NoError(t, err)
+
+		expected := []string{
+			"SOA", "@", "skipped",
+			"NS", "@", "skipped",
+			"A", "@", "skipped",
+			"MX", "@", "created",
+			"MX", "@", "created",
+			"TXT", "@", "created",
+			"A", "www", "created",
+			"CNAME", "cdn", "created",
+			"CNAME", "*", "created",
+			"SRV", "_sip._tcp", "created",
+			"CAA", "@", "skipped",
+			"A", "www.other.com", "skipped",
+			"A", "notexample.com", "skipped",
+		}
+		assert.Equal(t, expected, strings.Fields(buf.String()))
+	})
+}
+
+func TestRecordImportCloudflare(t *testing.T) {
+	path := writeTestExport(t, `{"result": [
+		{"type": "A", "name": "example.com", "content": "10.0.0.1", "proxied": true},
+		{"type": "MX", "name": "example.com", "content": "mail.example.com", "priority": 10},
+		{"type": "CNAME", "name": "www.example.com", "content": "example.com"},
+		{"type": "A", "name": "www.other.com", "content": "10.0.0.2"}
+	]}`)
+	defer os.Remove(path)
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("CreateRecord", "example.com", &godo.DomainRecordEditRequest{Type: "A", Name: "@", Data: "10.0.0.1"}).Return(&testRecord, nil)
+		tm.domains.On("CreateRecord", "example.com", &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10}).Return(&testRecord, nil)
+		tm.domains.On("CreateRecord", "example.com", &godo.DomainRecordEditRequest{Type: "CNAME", Name: "www", Data: "example.com."}).Return(nil, errors.New("conflict"))
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgProvider, "cloudflare")
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+		config.Doit.Set(config.NS, doctl.ArgFormat, "Type,Status")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunRecordImport(config)
+		assert.EqualError(t, err, "failed to import 1 records")
+		assert.Equal(t, []string{"A", "created", "MX", "created", "CNAME", "failed", "A", "skipped"}, strings.Fields(buf.String()))
+	})
+}
+
+func TestRecordImportUnknownProvider(t *testing.T) {
+	path := writeTestExport(t, `{}`)
+	defer os.Remove(path)
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgProvider, "bind")
+		config.Doit.Set(config.NS, doctl.ArgFile, path)
+
+		err := RunRecordImport(config)
+		assert.Error(t, err)
+	})
+}
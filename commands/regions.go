@@ -13,7 +13,11 @@ limitations under the License.
 
 package commands
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/cobra"
+)
 
 // Region creates the region commands heirarchy.
 func Region() *Command {
@@ -25,8 +29,10 @@ func Region() *Command {
 		},
 	}
 
-	CmdBuilder(cmd, RunRegionList, "list", "list regions", Writer, aliasOpt("ls"),
+	cmdRegionList := CmdBuilder(cmd, RunRegionList, "list", "list regions", Writer, aliasOpt("ls"),
 		displayerType(&region{}), docCategories("compute"))
+	AddStringSliceFlag(cmdRegionList, doctl.ArgFeatures, []string{}, "Features the region must support")
+	AddBoolFlag(cmdRegionList, doctl.ArgAvailable, false, "Only list available regions")
 
 	return cmd
 }
@@ -35,11 +41,52 @@ func Region() *Command {
 func RunRegionList(c *CmdConfig) error {
 	rs := c.Regions()
 
+	features, err := c.Doit.GetStringSlice(c.NS, doctl.ArgFeatures)
+	if err != nil {
+		return err
+	}
+
+	available, err := c.Doit.GetBool(c.NS, doctl.ArgAvailable)
+	if err != nil {
+		return err
+	}
+
 	list, err := rs.List()
 	if err != nil {
 		return err
 	}
 
-	image := &region{regions: list}
+	var matched do.Regions
+	for _, r := range list {
+		if available && !r.Available {
+			continue
+		}
+
+		if !regionHasFeatures(r, features) {
+			continue
+		}
+
+		matched = append(matched, r)
+	}
+
+	image := &region{regions: matched}
 	return c.Display(image)
 }
+
+func regionHasFeatures(r do.Region, features []string) bool {
+	for _, f := range features {
+		found := false
+		for _, rf := range r.Features {
+			if rf == f {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -14,8 +14,11 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +43,38 @@ func TestRegionsList(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRegionsListFilters(t *testing.T) {
+	list := do.Regions{
+		{Region: &godo.Region{Slug: "nyc1", Available: true, Features: []string{"private_networking", "metadata"}}},
+		{Region: &godo.Region{Slug: "nyc3", Available: true, Features: []string{"private_networking", "metadata", "storage"}}},
+		{Region: &godo.Region{Slug: "ams1", Available: false, Features: []string{"metadata", "storage"}}},
+	}
+
+	cases := []struct {
+		features  []string
+		available bool
+		expected  []string
+	}{
+		{features: []string{"storage", "metadata"}, expected: []string{"nyc3", "ams1"}},
+		{features: []string{"storage"}, available: true, expected: []string{"nyc3"}},
+		{available: true, expected: []string{"nyc1", "nyc3"}},
+	}
+
+	for _, c := range cases {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.regions.On("List").Return(list, nil)
+
+			var buf bytes.Buffer
+			config.Out = &buf
+			config.Doit.Set(config.NS, doctl.ArgFeatures, c.features)
+			config.Doit.Set(config.NS, doctl.ArgAvailable, c.available)
+			config.Doit.Set(config.NS, doctl.ArgFormat, "Slug")
+			config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+			err := RunRegionList(config)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, strings.Fields(buf.String()))
+		})
+	}
+}
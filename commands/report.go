@@ -0,0 +1,210 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// Estimated prices used for the orphaned resource report. The API does not
+// report them, so they follow the published pricing.
+var (
+	volumePricePerGBMonthly   = 0.10
+	snapshotPricePerGBMonthly = 0.05
+	floatingIPPriceHourly     = 0.006
+)
+
+// billableHoursPerMonth is the number of hours after which hourly billing
+// stops for the month.
+const billableHoursPerMonth = 672
+
+// orphan is a resource which is billed but not in use.
+type orphan struct {
+	URN          string  `json:"urn"`
+	Type         string  `json:"type"`
+	Name         string  `json:"name"`
+	Reason       string  `json:"reason"`
+	MonthlyCost  float64 `json:"estimated_monthly_cost"`
+	CostIsApprox bool    `json:"cost_is_upper_bound,omitempty"`
+}
+
+// Report creates the report command.
+func Report() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "report",
+			Short: "report commands",
+			Long:  "report is used to access reports about account resources",
+		},
+		DocCategories: []string{"report"},
+		IsIndex:       true,
+	}
+
+	CmdBuilder(cmd, RunReportOrphans, "orphans", "list resources which are billed but not in use", Writer,
+		displayerType(&orphans{}), docCategories("report"))
+
+	return cmd
+}
+
+// RunReportOrphans lists unattached volumes, unassigned floating IPs and
+// snapshots of deleted droplets with their estimated monthly cost.
+func RunReportOrphans(c *CmdConfig) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	collect := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var volumes, fips, snapshots []orphan
+	var dropletSnapshots map[int]bool
+
+	collect(func() (err error) {
+		volumes, err = orphanVolumes(c)
+		return err
+	})
+	collect(func() (err error) {
+		fips, err = orphanFloatingIPs(c)
+		return err
+	})
+	collect(func() error {
+		list, err := c.Droplets().List()
+		if err != nil {
+			return err
+		}
+
+		dropletSnapshots = map[int]bool{}
+		for _, d := range list {
+			for _, id := range d.SnapshotIDs {
+				dropletSnapshots[id] = true
+			}
+		}
+		return nil
+	})
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	snapshots, err := orphanSnapshots(c, dropletSnapshots)
+	if err != nil {
+		return err
+	}
+
+	var list []orphan
+	list = append(list, volumes...)
+	list = append(list, fips...)
+	list = append(list, snapshots...)
+
+	return c.Display(&orphans{orphans: list})
+}
+
+func orphanVolumes(c *CmdConfig) ([]orphan, error) {
+	list, err := c.Volumes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []orphan
+	for _, v := range list {
+		if len(v.DropletIDs) > 0 {
+			continue
+		}
+
+		out = append(out, orphan{
+			URN:         formatURN("volume", v.ID),
+			Type:        "volume",
+			Name:        v.Name,
+			Reason:      "not attached to a droplet",
+			MonthlyCost: float64(v.SizeGigaBytes) * volumePricePerGBMonthly,
+		})
+	}
+
+	return out, nil
+}
+
+func orphanFloatingIPs(c *CmdConfig) ([]orphan, error) {
+	list, err := c.FloatingIPs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []orphan
+	for _, f := range list {
+		if f.Droplet != nil {
+			continue
+		}
+
+		out = append(out, orphan{
+			URN:         formatURN("floatingip", f.IP),
+			Type:        "floatingip",
+			Name:        f.IP,
+			Reason:      "not assigned to a droplet",
+			MonthlyCost: floatingIPPriceHourly * billableHoursPerMonth,
+		})
+	}
+
+	return out, nil
+}
+
+// orphanSnapshots lists snapshots which do not belong to an existing droplet.
+// Snapshot sizes are not reported by the API, so the cost uses the minimum
+// disk size and is an upper bound.
+func orphanSnapshots(c *CmdConfig, dropletSnapshots map[int]bool) ([]orphan, error) {
+	list, err := c.Images().ListUser(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []orphan
+	for _, i := range list {
+		if i.Type != "snapshot" || dropletSnapshots[i.ID] {
+			continue
+		}
+
+		out = append(out, orphan{
+			URN:          formatURN("image", i.ID),
+			Type:         "snapshot",
+			Name:         i.Name,
+			Reason:       "droplet no longer exists",
+			MonthlyCost:  float64(i.MinDiskSize) * snapshotPricePerGBMonthly,
+			CostIsApprox: true,
+		})
+	}
+
+	return out, nil
+}
+
+func formatCost(cost float64, upperBound bool) string {
+	s := fmt.Sprintf("$%.2f", cost)
+	if upperBound {
+		s = "<= " + s
+	}
+	return s
+}
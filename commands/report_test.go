@@ -0,0 +1,73 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCommand(t *testing.T) {
+	cmd := Report()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "orphans")
+}
+
+func TestReportOrphans(t *testing.T) {
+	volumes := []do.Volume{
+		{Volume: &godo.Volume{ID: "vol-1", Name: "attached", SizeGigaBytes: 10, DropletIDs: []int{1}}},
+		{Volume: &godo.Volume{ID: "vol-2", Name: "loose", SizeGigaBytes: 100}},
+	}
+	fips := do.FloatingIPs{
+		{FloatingIP: &godo.FloatingIP{IP: "45.55.1.1", Droplet: testDroplet.Droplet}},
+		{FloatingIP: &godo.FloatingIP{IP: "45.55.1.2"}},
+	}
+	owned := do.Droplet{Droplet: &godo.Droplet{ID: 1, SnapshotIDs: []int{5}}}
+	images := do.Images{
+		{Image: &godo.Image{ID: 5, Name: "kept", Type: "snapshot", MinDiskSize: 20}},
+		{Image: &godo.Image{ID: 6, Name: "stale", Type: "snapshot", MinDiskSize: 20}},
+		{Image: &godo.Image{ID: 7, Name: "backup", Type: "backup", MinDiskSize: 20}},
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.volumes.On("List").Return(volumes, nil)
+		tm.floatingIPs.On("List").Return(fips, nil)
+		tm.droplets.On("List").Return(do.Droplets{owned}, nil)
+		tm.images.On("ListUser", false).Return(images, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFormat, "URN,MonthlyCost")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunReportOrphans(config)
+		assert.NoError(t, err)
+
+		var rows []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			rows = append(rows, strings.Join(strings.Fields(line), " "))
+		}
+		assert.Equal(t, []string{
+			"do:volume:vol-2 $10.00",
+			"do:floatingip:45.55.1.2 $4.03",
+			"do:image:6 <= $1.00",
+		}, rows)
+	})
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/cobra"
+)
+
+// urnPrefix begins every DigitalOcean resource URN.
+const urnPrefix = "do"
+
+// urnResolvers fetch the resource a URN names, keyed by resource type.
+var urnResolvers = map[string]func(c *CmdConfig, id string) (Displayable, error){
+	"droplet": func(c *CmdConfig, id string) (Displayable, error) {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid droplet id %q", id)
+		}
+		d, err := c.Droplets().Get(n)
+		if err != nil {
+			return nil, err
+		}
+		return &droplet{droplets: do.Droplets{*d}}, nil
+	},
+	"domain": func(c *CmdConfig, id string) (Displayable, error) {
+		d, err := c.Domains().Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &domain{domains: do.Domains{*d}}, nil
+	},
+	"volume": func(c *CmdConfig, id string) (Displayable, error) {
+		v, err := c.Volumes().Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &volume{volumes: []do.Volume{*v}}, nil
+	},
+	"floatingip": func(c *CmdConfig, id string) (Displayable, error) {
+		f, err := c.FloatingIPs().Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &floatingIP{floatingIPs: do.FloatingIPs{*f}}, nil
+	},
+	"image": func(c *CmdConfig, id string) (Displayable, error) {
+		var i *do.Image
+		var err error
+		if n, aerr := strconv.Atoi(id); aerr == nil {
+			i, err = c.Images().GetByID(n)
+		} else {
+			i, err = c.Images().GetBySlug(id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &image{images: do.Images{*i}}, nil
+	},
+	"sshkey": func(c *CmdConfig, id string) (Displayable, error) {
+		k, err := c.Keys().Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &key{keys: do.SSHKeys{*k}}, nil
+	},
+	"tag": func(c *CmdConfig, id string) (Displayable, error) {
+		t, err := c.Tags().Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &tag{tags: do.Tags{*t}}, nil
+	},
+	"action": func(c *CmdConfig, id string) (Displayable, error) {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action id %q", id)
+		}
+		a, err := c.Actions().Get(n)
+		if err != nil {
+			return nil, err
+		}
+		return &action{actions: do.Actions{*a}}, nil
+	},
+}
+
+// Resource creates the resource command.
+func Resource() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "resource",
+			Short: "resource commands",
+			Long:  "resource is used to access resources by URN, e.g. do:droplet:123",
+		},
+		DocCategories: []string{"resource"},
+		IsIndex:       true,
+	}
+
+	CmdBuilder(cmd, RunResourceGet, "get <urn>", "get a resource by URN", Writer,
+		aliasOpt("g"), docCategories("resource"))
+
+	return cmd
+}
+
+// RunResourceGet displays the resource a URN names.
+func RunResourceGet(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	kind, id, err := parseURN(c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	item, err := urnResolvers[kind](c, id)
+	if err != nil {
+		return err
+	}
+
+	return c.Display(item)
+}
+
+// formatURN builds the URN of a resource.
+func formatURN(kind string, id interface{}) string {
+	return fmt.Sprintf("%s:%s:%v", urnPrefix, kind, id)
+}
+
+// parseURN splits a URN into its resource type and id.
+func parseURN(urn string) (string, string, error) {
+	parts := strings.SplitN(urn, ":", 3)
+	if len(parts) != 3 || parts[0] != urnPrefix || parts[2] == "" {
+		return "", "", fmt.Errorf("invalid URN %q, expected do:<type>:<id>", urn)
+	}
+
+	kind := strings.ToLower(parts[1])
+	if _, ok := urnResolvers[kind]; !ok {
+		var kinds []string
+		for k := range urnResolvers {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		return "", "", fmt.Errorf("unknown resource type %q in URN, expected one of %s", parts[1], strings.Join(kinds, ", "))
+	}
+
+	return kind, parts[2], nil
+}
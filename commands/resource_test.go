@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceCommand(t *testing.T) {
+	cmd := Resource()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "get")
+}
+
+func TestParseURN(t *testing.T) {
+	kind, id, err := parseURN("do:droplet:123")
+	assert.NoError(t, err)
+	assert.Equal(t, "droplet", kind)
+	assert.Equal(t, "123", id)
+
+	kind, id, err = parseURN("do:domain:example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "domain", kind)
+	assert.Equal(t, "example.com", id)
+
+	for _, urn := range []string{"droplet:123", "do:droplet:", "aws:droplet:1", "do:cluster:abc"} {
+		_, _, err := parseURN(urn)
+		assert.Error(t, err, urn)
+	}
+
+	assert.Equal(t, "do:floatingip:1.2.3.4", formatURN("floatingip", "1.2.3.4"))
+}
+
+func TestResourceGetDroplet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+
+		config.Args = append(config.Args, "do:droplet:1")
+
+		err := RunResourceGet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestResourceGetImageBySlug(t *testing.T) {
+	i := &do.Image{Image: &godo.Image{ID: 2, Slug: "ubuntu-16-04-x64"}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.images.On("GetBySlug", "ubuntu-16-04-x64").Return(i, nil)
+
+		config.Args = append(config.Args, "do:image:ubuntu-16-04-x64")
+
+		err := RunResourceGet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestResourceGetDomain(t *testing.T) {
+	d := &do.Domain{Domain: &godo.Domain{Name: "example.com"}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.On("Get", "example.com").Return(d, nil)
+
+		config.Args = append(config.Args, "do:domain:example.com")
+
+		err := RunResourceGet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestResourceGetInvalidID(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "do:droplet:web")
+
+		err := RunResourceGet(config)
+		assert.Error(t, err)
+	})
+}
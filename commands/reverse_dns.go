@@ -0,0 +1,157 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+)
+
+// DigitalOcean serves the PTR record of a droplet's public addresses from
+// the droplet's name, so reverse DNS is set by renaming the droplet to a
+// fully qualified hostname. Floating IPs have no settable PTR.
+
+var (
+	lookupAddrFunc = net.LookupAddr
+	lookupHostFunc = net.LookupHost
+)
+
+// reverseDNSCheck is the PTR record of an address and whether the name it
+// points at resolves back to the address.
+type reverseDNSCheck struct {
+	IP      string   `json:"ip"`
+	PTR     string   `json:"ptr"`
+	Forward []string `json:"forward"`
+	Match   bool     `json:"match"`
+}
+
+func checkReverseDNS(ip string) reverseDNSCheck {
+	rc := reverseDNSCheck{IP: ip}
+
+	names, err := lookupAddrFunc(ip)
+	if err != nil || len(names) == 0 {
+		return rc
+	}
+	rc.PTR = strings.TrimSuffix(names[0], ".")
+
+	rc.Forward, _ = lookupHostFunc(rc.PTR)
+	rc.Match = containsIP(rc.Forward, ip)
+
+	return rc
+}
+
+func containsIP(ips []string, ip string) bool {
+	want := net.ParseIP(ip)
+	for _, s := range ips {
+		if got := net.ParseIP(s); got != nil && got.Equal(want) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dropletPublicIPs returns the public IPv4 and IPv6 addresses of d.
+func dropletPublicIPs(d *do.Droplet) []string {
+	ips := []string{}
+	if ip, err := d.PublicIPv4(); err == nil && ip != "" {
+		ips = append(ips, ip)
+	}
+	if ip, err := d.PublicIPv6(); err == nil && ip != "" {
+		ips = append(ips, ip)
+	}
+
+	return ips
+}
+
+// RunDropletReverseDNS shows the reverse DNS of a droplet's public
+// addresses.
+func RunDropletReverseDNS(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	d, err := resolveDroplet(c.Droplets(), c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	checks := reverseDNS{}
+	for _, ip := range dropletPublicIPs(d) {
+		checks = append(checks, checkReverseDNS(ip))
+	}
+
+	return c.Display(&checks)
+}
+
+// RunDropletSetReverseDNS sets the reverse DNS of a droplet by renaming it
+// to a hostname. Unless forced, the hostname must already resolve to the
+// droplet's public IPv4 address so forward and reverse records match.
+func RunDropletSetReverseDNS(c *CmdConfig) error {
+	if len(c.Args) != 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	hostname := strings.TrimSuffix(c.Args[1], ".")
+	if !strings.Contains(hostname, ".") {
+		return fmt.Errorf("%q is not a fully qualified hostname", hostname)
+	}
+
+	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
+	if err != nil {
+		return err
+	}
+
+	d, err := resolveDroplet(c.Droplets(), c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		ip, err := d.PublicIPv4()
+		if err != nil || ip == "" {
+			return fmt.Errorf("droplet %d has no public IPv4 address", d.ID)
+		}
+
+		forward, err := lookupHostFunc(hostname)
+		if err != nil || !containsIP(forward, ip) {
+			return fmt.Errorf("%s doesn't resolve to %s, create its A record first or use --%s",
+				hostname, ip, doctl.ArgForce)
+		}
+	}
+
+	fn := func(das do.DropletActionsService) (*do.Action, error) {
+		return das.Rename(d.ID, hostname)
+	}
+
+	return performAction(c, fn)
+}
+
+// RunFloatingIPReverseDNS shows the reverse DNS of a floating IP.
+func RunFloatingIPReverseDNS(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	fip, err := c.FloatingIPs().Get(c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.Display(&reverseDNS{checkReverseDNS(fip.IP)})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/stretchr/testify/assert"
+)
+
+func withFakeResolver(t *testing.T, ptr map[string]string, hosts map[string][]string, fn func()) {
+	defer func(la, lh func(string) ([]string, error)) {
+		lookupAddrFunc, lookupHostFunc = la, lh
+	}(lookupAddrFunc, lookupHostFunc)
+
+	lookupAddrFunc = func(ip string) ([]string, error) {
+		if name, ok := ptr[ip]; ok {
+			return []string{name}, nil
+		}
+		return nil, errors.New("no PTR")
+	}
+	lookupHostFunc = func(host string) ([]string, error) {
+		if ips, ok := hosts[host]; ok {
+			return ips, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	fn()
+}
+
+func TestCheckReverseDNS(t *testing.T) {
+	ptr := map[string]string{"8.8.8.8": "web.example.com.", "8.8.4.4": "stale.example.com."}
+	hosts := map[string][]string{"web.example.com": {"8.8.8.8"}, "stale.example.com": {"10.0.0.1"}}
+
+	withFakeResolver(t, ptr, hosts, func() {
+		assert.Equal(t, reverseDNSCheck{IP: "8.8.8.8", PTR: "web.example.com", Forward: []string{"8.8.8.8"}, Match: true},
+			checkReverseDNS("8.8.8.8"))
+		assert.False(t, checkReverseDNS("8.8.4.4").Match)
+		assert.Equal(t, reverseDNSCheck{IP: "1.1.1.1"}, checkReverseDNS("1.1.1.1"))
+	})
+}
+
+func TestDropletReverseDNS(t *testing.T) {
+	withFakeResolver(t, nil, nil, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+
+			config.Args = append(config.Args, "1")
+
+			err := RunDropletReverseDNS(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestDropletSetReverseDNS(t *testing.T) {
+	hosts := map[string][]string{"web.example.com": {"8.8.8.8"}}
+
+	withFakeResolver(t, nil, hosts, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+			tm.dropletActions.On("Rename", 1, "web.example.com").Return(&testAction, nil)
+
+			config.Args = append(config.Args, "1", "web.example.com.")
+
+			err := RunDropletSetReverseDNS(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestDropletSetReverseDNSMismatch(t *testing.T) {
+	hosts := map[string][]string{"web.example.com": {"10.0.0.1"}}
+
+	withFakeResolver(t, nil, hosts, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+
+			config.Args = append(config.Args, "1", "web.example.com")
+
+			err := RunDropletSetReverseDNS(config)
+			assert.Error(t, err)
+			tm.dropletActions.AssertNotCalled(t, "Rename", 1, "web.example.com")
+		})
+	})
+}
+
+func TestDropletSetReverseDNSForce(t *testing.T) {
+	withFakeResolver(t, nil, nil, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("Get", 1).Return(&testDroplet, nil)
+			tm.dropletActions.On("Rename", 1, "web.example.com").Return(&testAction, nil)
+
+			config.Doit.Set(config.NS, doctl.ArgForce, true)
+			config.Args = append(config.Args, "1", "web.example.com")
+
+			err := RunDropletSetReverseDNS(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestDropletSetReverseDNSNotQualified(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "1", "web")
+
+		err := RunDropletSetReverseDNS(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestFloatingIPReverseDNS(t *testing.T) {
+	withFakeResolver(t, nil, nil, func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.floatingIPs.On("Get", "127.0.0.1").Return(&testFloatingIP, nil)
+
+			config.Args = append(config.Args, "127.0.0.1")
+
+			err := RunFloatingIPReverseDNS(config)
+			assert.NoError(t, err)
+		})
+	})
+}
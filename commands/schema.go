@@ -0,0 +1,147 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// requiredUsageRE matches the marker requiredOpt appends to flag usage.
+var requiredUsageRE = regexp.MustCompile(`\s*(\x1b\[[0-9;]*m)*\(required\)(\x1b\[[0-9;]*m)*$`)
+
+type commandSchema struct {
+	Name     string          `json:"name" yaml:"name"`
+	Path     string          `json:"path" yaml:"path"`
+	Usage    string          `json:"usage" yaml:"usage"`
+	Short    string          `json:"short,omitempty" yaml:"short,omitempty"`
+	Long     string          `json:"long,omitempty" yaml:"long,omitempty"`
+	Aliases  []string        `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Runnable bool            `json:"runnable" yaml:"runnable"`
+	Flags    []flagSchema    `json:"flags,omitempty" yaml:"flags,omitempty"`
+	Commands []commandSchema `json:"commands,omitempty" yaml:"commands,omitempty"`
+}
+
+type flagSchema struct {
+	Name       string `json:"name" yaml:"name"`
+	Shorthand  string `json:"shorthand,omitempty" yaml:"shorthand,omitempty"`
+	Type       string `json:"type" yaml:"type"`
+	Default    string `json:"default" yaml:"default"`
+	Usage      string `json:"usage" yaml:"usage"`
+	Required   bool   `json:"required" yaml:"required"`
+	Persistent bool   `json:"persistent" yaml:"persistent"`
+}
+
+// Commands creates the commands command.
+func Commands() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "commands",
+			Short: "commands commands",
+			Long:  "commands is used to inspect the doctl command tree",
+		},
+	}
+
+	// export does not talk to the API, so it is built without CmdBuilder to
+	// avoid requiring an access token.
+	cmd.AddCommand(&Command{
+		Command: &cobra.Command{
+			Use:   "export",
+			Short: "export the command tree, flags and defaults",
+			Long:  "export the command tree, flags and defaults",
+			Run: func(cmd *cobra.Command, args []string) {
+				checkErr(RunCommandsExport(Writer), cmd)
+			},
+		},
+	})
+
+	return cmd
+}
+
+// RunCommandsExport writes the full command tree as json or yaml so other
+// tools can stay in sync with doctl.
+func RunCommandsExport(out io.Writer) error {
+	output, err := doctl.DoitConfig.GetString(doctl.NSRoot, doctl.ArgOutput)
+	if err != nil {
+		return err
+	}
+
+	schema := buildCommandSchema(DoitCmd.Command)
+
+	switch output {
+	case "json":
+		return writeJSON(schema, out)
+	case "", "text", "yaml":
+		b, err := yaml.Marshal(schema)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(b)
+		return err
+	default:
+		return fmt.Errorf("unknown output type")
+	}
+}
+
+func buildCommandSchema(cmd *cobra.Command) commandSchema {
+	cs := commandSchema{
+		Name:     cmd.Name(),
+		Path:     cmd.CommandPath(),
+		Usage:    cmd.UseLine(),
+		Short:    cmd.Short,
+		Aliases:  cmd.Aliases,
+		Runnable: cmd.Runnable(),
+	}
+	if cmd.Long != cmd.Short {
+		cs.Long = cmd.Long
+	}
+
+	cmd.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		cs.Flags = append(cs.Flags, buildFlagSchema(cmd, f))
+	})
+
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() {
+			continue
+		}
+		cs.Commands = append(cs.Commands, buildCommandSchema(child))
+	}
+
+	return cs
+}
+
+func buildFlagSchema(cmd *cobra.Command, f *pflag.Flag) flagSchema {
+	required := viper.GetBool(requiredKey(fmt.Sprintf("%s.%s", cmdNS(cmd), f.Name)))
+
+	return flagSchema{
+		Name:       f.Name,
+		Shorthand:  f.Shorthand,
+		Type:       f.Value.Type(),
+		Default:    f.DefValue,
+		Usage:      requiredUsageRE.ReplaceAllString(f.Usage, ""),
+		Required:   required,
+		Persistent: cmd.PersistentFlags().Lookup(f.Name) != nil,
+	}
+}
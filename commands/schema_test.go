@@ -0,0 +1,59 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCommandSchema(t *testing.T) {
+	root := &Command{Command: &cobra.Command{Use: "doctl"}}
+	root.PersistentFlags().StringP("output", "o", "text", "output format")
+
+	parent := &Command{Command: &cobra.Command{Use: "droplet", Short: "droplet commands"}}
+	root.AddCommand(parent)
+
+	cmd := CmdBuilder(parent, func(*CmdConfig) error { return nil }, "create", "create droplet", Writer, aliasOpt("c"))
+	AddStringFlag(cmd, doctl.ArgSizeSlug, "", "Droplet size", requiredOpt())
+	AddIntFlag(cmd, doctl.ArgCount, 1, "Count")
+	AddStringFlag(cmd, "secret", "", "Hidden")
+	cmd.Flag("secret").Hidden = true
+
+	hidden := CmdBuilder(parent, func(*CmdConfig) error { return nil }, "beta", "beta", Writer)
+	hidden.Hidden = true
+
+	s := buildCommandSchema(root.Command)
+	assert.Equal(t, "doctl", s.Name)
+	assert.False(t, s.Runnable)
+	assert.Equal(t, []flagSchema{
+		{Name: "output", Shorthand: "o", Type: "string", Default: "text", Usage: "output format", Persistent: true},
+	}, s.Flags)
+
+	assert.Len(t, s.Commands, 1)
+	assert.Len(t, s.Commands[0].Commands, 1)
+
+	create := s.Commands[0].Commands[0]
+	assert.Equal(t, "doctl droplet create", create.Path)
+	assert.Equal(t, []string{"c"}, create.Aliases)
+	assert.True(t, create.Runnable)
+	assert.Empty(t, create.Long)
+	assert.Equal(t, []flagSchema{
+		{Name: doctl.ArgCount, Type: "int", Default: "1", Usage: "Count"},
+		{Name: doctl.ArgSizeSlug, Type: "string", Usage: "Droplet size", Required: true},
+	}, create.Flags)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl"
+)
+
+// searchResult is a resource matching a search term.
+type searchResult struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	URN   string `json:"urn"`
+	Match string `json:"match"`
+}
+
+// searchFn finds the resources of one type matching term.
+type searchFn func(c *CmdConfig, term string) ([]searchResult, error)
+
+// searchers query each resource type searched by doctl search.
+var searchers = []searchFn{
+	searchDroplets,
+	searchDomains,
+	searchVolumes,
+	searchSnapshots,
+	searchFloatingIPs,
+}
+
+// Search creates the search command.
+func Search() *Command {
+	return CmdBuilder(nil, RunSearch, "search <term>", "search resources by name or IP address", Writer,
+		displayerType(&searchResults{}), docCategories("search"))
+}
+
+// RunSearch searches droplets, domains, volumes, snapshots and floating IPs
+// for names or IP addresses containing a term.
+func RunSearch(c *CmdConfig) error {
+	if len(c.Args) != 1 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	term := strings.ToLower(c.Args[0])
+
+	var wg sync.WaitGroup
+	found := make([][]searchResult, len(searchers))
+	errs := make([]error, len(searchers))
+
+	for i, fn := range searchers {
+		wg.Add(1)
+		go func(i int, fn searchFn) {
+			defer wg.Done()
+			found[i], errs[i] = fn(c, term)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	var results []searchResult
+	for i := range searchers {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		results = append(results, found[i]...)
+	}
+
+	sort.Stable(searchResultsByType(results))
+	return c.Display(&searchResults{results: results})
+}
+
+func searchMatch(term string, values ...string) (string, bool) {
+	for _, v := range values {
+		if v != "" && strings.Contains(strings.ToLower(v), term) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func searchDroplets(c *CmdConfig, term string) ([]searchResult, error) {
+	list, err := c.Droplets().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchResult
+	for _, d := range list {
+		values := []string{d.Name}
+		if d.Networks != nil {
+			for _, n := range d.Networks.V4 {
+				values = append(values, n.IPAddress)
+			}
+			for _, n := range d.Networks.V6 {
+				values = append(values, n.IPAddress)
+			}
+		}
+
+		if m, ok := searchMatch(term, values...); ok {
+			results = append(results, searchResult{Type: "droplet", Name: d.Name, URN: formatURN("droplet", d.ID), Match: m})
+		}
+	}
+
+	return results, nil
+}
+
+func searchDomains(c *CmdConfig, term string) ([]searchResult, error) {
+	list, err := c.Domains().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchResult
+	for _, d := range list {
+		if m, ok := searchMatch(term, d.Name); ok {
+			results = append(results, searchResult{Type: "domain", Name: d.Name, URN: formatURN("domain", d.Name), Match: m})
+		}
+	}
+
+	return results, nil
+}
+
+func searchVolumes(c *CmdConfig, term string) ([]searchResult, error) {
+	list, err := c.Volumes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchResult
+	for _, v := range list {
+		if m, ok := searchMatch(term, v.Name); ok {
+			results = append(results, searchResult{Type: "volume", Name: v.Name, URN: formatURN("volume", v.ID), Match: m})
+		}
+	}
+
+	return results, nil
+}
+
+func searchSnapshots(c *CmdConfig, term string) ([]searchResult, error) {
+	list, err := c.Images().ListUser(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchResult
+	for _, i := range list {
+		if i.Type != "snapshot" {
+			continue
+		}
+		if m, ok := searchMatch(term, i.Name); ok {
+			results = append(results, searchResult{Type: "snapshot", Name: i.Name, URN: formatURN("image", i.ID), Match: m})
+		}
+	}
+
+	return results, nil
+}
+
+func searchFloatingIPs(c *CmdConfig, term string) ([]searchResult, error) {
+	list, err := c.FloatingIPs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchResult
+	for _, f := range list {
+		name := ""
+		if f.Droplet != nil {
+			name = f.Droplet.Name
+		}
+
+		if m, ok := searchMatch(term, f.IP, name); ok {
+			results = append(results, searchResult{Type: "floatingip", Name: f.IP, URN: formatURN("floatingip", f.IP), Match: m})
+		}
+	}
+
+	return results, nil
+}
+
+type searchResultsByType []searchResult
+
+func (s searchResultsByType) Len() int      { return len(s) }
+func (s searchResultsByType) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s searchResultsByType) Less(i, j int) bool {
+	if s[i].Type != s[j].Type {
+		return s[i].Type < s[j].Type
+	}
+	return s[i].Name < s[j].Name
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	domains := do.Domains{
+		{Domain: &godo.Domain{Name: "example.com"}},
+		{Domain: &godo.Domain{Name: "another-droplet.io"}},
+	}
+	volumes := []do.Volume{
+		{Volume: &godo.Volume{ID: "vol-1", Name: "data"}},
+	}
+	images := do.Images{
+		{Image: &godo.Image{ID: 5, Name: "another-droplet-nightly", Type: "snapshot"}},
+		{Image: &godo.Image{ID: 6, Name: "another-droplet-backup", Type: "backup"}},
+	}
+	fips := do.FloatingIPs{
+		{FloatingIP: &godo.FloatingIP{IP: "45.55.1.1", Droplet: anotherTestDroplet.Droplet}},
+		{FloatingIP: &godo.FloatingIP{IP: "45.55.1.2"}},
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.domains.On("List").Return(domains, nil)
+		tm.volumes.On("List").Return(volumes, nil)
+		tm.images.On("ListUser", false).Return(images, nil)
+		tm.floatingIPs.On("List").Return(fips, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "Another-Droplet")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunSearch(config)
+		assert.NoError(t, err)
+
+		var rows []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			rows = append(rows, strings.Join(strings.Fields(line), " "))
+		}
+		assert.Equal(t, []string{
+			"do:domain:another-droplet.io domain another-droplet.io another-droplet.io",
+			"do:droplet:3 droplet another-droplet another-droplet",
+			"do:floatingip:45.55.1.1 floatingip 45.55.1.1 another-droplet",
+			"do:image:5 snapshot another-droplet-nightly another-droplet-nightly",
+		}, rows)
+	})
+}
+
+func TestSearchByIP(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.domains.On("List").Return(do.Domains{}, nil)
+		tm.volumes.On("List").Return([]do.Volume{}, nil)
+		tm.images.On("ListUser", false).Return(do.Images{}, nil)
+		tm.floatingIPs.On("List").Return(do.FloatingIPs{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "172.16.1.4")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunSearch(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "do:droplet:3 droplet another-droplet 172.16.1.4", strings.Join(strings.Fields(buf.String()), " "))
+	})
+}
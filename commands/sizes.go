@@ -13,7 +13,16 @@ limitations under the License.
 
 package commands
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/cobra"
+)
 
 // Size creates the size commands heirarchy.
 func Size() *Command {
@@ -25,8 +34,13 @@ func Size() *Command {
 		},
 	}
 
-	CmdBuilder(cmd, RunSizeList, "list", "list sizes", Writer, aliasOpt("ls"),
+	cmdSizeList := CmdBuilder(cmd, RunSizeList, "list", "list sizes; filtered lists are sorted cheapest first", Writer, aliasOpt("ls"),
 		displayerType(&size{}), docCategories("compute"))
+	AddIntFlag(cmdSizeList, doctl.ArgMinVCPUs, 0, "Minimum number of VCPUs")
+	AddIntFlag(cmdSizeList, doctl.ArgMinMemory, 0, "Minimum memory in MB")
+	AddStringFlag(cmdSizeList, doctl.ArgMaxPriceMonthly, "", "Maximum monthly price")
+	AddStringFlag(cmdSizeList, doctl.ArgRegionSlug, "", "Region the size must be available in")
+	AddBoolFlag(cmdSizeList, doctl.ArgGPUs, false, "Only list GPU sizes")
 
 	return cmd
 }
@@ -35,11 +49,131 @@ func Size() *Command {
 func RunSizeList(c *CmdConfig) error {
 	sizes := c.Sizes()
 
+	minVCPUs, err := c.Doit.GetInt(c.NS, doctl.ArgMinVCPUs)
+	if err != nil {
+		return err
+	}
+
+	minMemory, err := c.Doit.GetInt(c.NS, doctl.ArgMinMemory)
+	if err != nil {
+		return err
+	}
+
+	maxPriceStr, err := c.Doit.GetString(c.NS, doctl.ArgMaxPriceMonthly)
+	if err != nil {
+		return err
+	}
+
+	var maxPrice float64
+	if maxPriceStr != "" {
+		maxPrice, err = strconv.ParseFloat(maxPriceStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid maximum monthly price %q", maxPriceStr)
+		}
+	}
+
+	region, err := c.Doit.GetString(c.NS, doctl.ArgRegionSlug)
+	if err != nil {
+		return err
+	}
+
+	gpus, err := c.Doit.GetBool(c.NS, doctl.ArgGPUs)
+	if err != nil {
+		return err
+	}
+
 	list, err := sizes.List()
 	if err != nil {
 		return err
 	}
 
-	item := &size{sizes: list}
+	var matched do.Sizes
+	for _, s := range list {
+		if gpus && !isGPUSize(s.Slug) {
+			continue
+		}
+
+		if s.Vcpus < minVCPUs || s.Memory < minMemory {
+			continue
+		}
+
+		if maxPriceStr != "" && s.PriceMonthly > maxPrice {
+			continue
+		}
+
+		if region != "" && !sizeInRegion(s, region) {
+			continue
+		}
+
+		matched = append(matched, s)
+	}
+
+	// Leave the API order alone for a plain listing; filters are used to
+	// pick a size, so show the cheapest match first.
+	filtered := minVCPUs > 0 || minMemory > 0 || maxPriceStr != "" || region != "" || gpus
+	if filtered {
+		sort.Stable(sizesByPrice(matched))
+	}
+
+	item := &size{sizes: matched}
 	return c.Display(item)
 }
+
+// isGPUSize reports whether slug names a GPU droplet size.
+func isGPUSize(slug string) bool {
+	return strings.HasPrefix(slug, "gpu-")
+}
+
+// checkGPUSizeRegion returns an error if slug is a GPU size that is not
+// available in region.
+func checkGPUSizeRegion(ss do.SizesService, slug, region string) error {
+	if !isGPUSize(slug) {
+		return nil
+	}
+
+	list, err := ss.List()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range list {
+		if s.Slug != slug {
+			continue
+		}
+
+		if sizeInRegion(s, region) {
+			return nil
+		}
+
+		return fmt.Errorf("GPU size %q is not available in region %q, available in: %s",
+			slug, region, strings.Join(s.Regions, ", "))
+	}
+
+	return fmt.Errorf("GPU size %q could not be found", slug)
+}
+
+func sizeInRegion(s do.Size, region string) bool {
+	if !s.Available {
+		return false
+	}
+
+	for _, r := range s.Regions {
+		if r == region {
+			return true
+		}
+	}
+
+	return false
+}
+
+type sizesByPrice do.Sizes
+
+func (s sizesByPrice) Len() int {
+	return len(s)
+}
+func (s sizesByPrice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s sizesByPrice) Less(i, j int) bool {
+	return s[i].PriceMonthly < s[j].PriceMonthly
+}
@@ -14,8 +14,11 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +43,101 @@ func TestSizesList(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestSizesListKeepsOrderWithoutFilters(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Sizes{
+			{Size: &godo.Size{Slug: "large", PriceMonthly: 80}},
+			{Size: &godo.Size{Slug: "small", PriceMonthly: 5}},
+		}
+		tm.sizes.On("List").Return(list, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFormat, "Slug")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunSizeList(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"large", "small"}, strings.Fields(buf.String()))
+	})
+}
+
+func TestSizesListFilters(t *testing.T) {
+	list := do.Sizes{
+		{Size: &godo.Size{Slug: "large", Vcpus: 4, Memory: 8192, PriceMonthly: 80, Available: true, Regions: []string{"nyc1"}}},
+		{Size: &godo.Size{Slug: "medium", Vcpus: 2, Memory: 2048, PriceMonthly: 20, Available: true, Regions: []string{"nyc1", "sfo1"}}},
+		{Size: &godo.Size{Slug: "small", Vcpus: 1, Memory: 512, PriceMonthly: 5, Available: true, Regions: []string{"sfo1"}}},
+		{Size: &godo.Size{Slug: "huge", Vcpus: 8, Memory: 16384, PriceMonthly: 160, Available: false, Regions: []string{"nyc1"}}},
+	}
+
+	cases := []struct {
+		key      string
+		value    interface{}
+		expected []string
+	}{
+		{key: doctl.ArgMinVCPUs, value: 2, expected: []string{"medium", "large", "huge"}},
+		{key: doctl.ArgMinMemory, value: 4096, expected: []string{"large", "huge"}},
+		{key: doctl.ArgMaxPriceMonthly, value: "20", expected: []string{"small", "medium"}},
+		{key: doctl.ArgRegionSlug, value: "nyc1", expected: []string{"medium", "large"}},
+	}
+
+	for _, c := range cases {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.sizes.On("List").Return(list, nil)
+
+			var buf bytes.Buffer
+			config.Out = &buf
+			config.Doit.Set(config.NS, c.key, c.value)
+			config.Doit.Set(config.NS, doctl.ArgFormat, "Slug")
+			config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+			err := RunSizeList(config)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, strings.Fields(buf.String()))
+		})
+	}
+}
+
+func TestSizesListInvalidPrice(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgMaxPriceMonthly, "cheap")
+
+		err := RunSizeList(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestSizesListGPUs(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Sizes{
+			{Size: &godo.Size{Slug: "gpu-h100x1-80gb", PriceMonthly: 2000}},
+			{Size: &godo.Size{Slug: "s-1vcpu-1gb", PriceMonthly: 5}},
+		}
+		tm.sizes.On("List").Return(list, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgGPUs, true)
+		config.Doit.Set(config.NS, doctl.ArgFormat, "Slug")
+		config.Doit.Set(config.NS, doctl.ArgNoHeader, true)
+
+		err := RunSizeList(config)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"gpu-h100x1-80gb"}, strings.Fields(buf.String()))
+	})
+}
+
+func TestCheckGPUSizeRegion(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		list := do.Sizes{
+			{Size: &godo.Size{Slug: "gpu-h100x1-80gb", Available: true, Regions: []string{"tor1"}}},
+		}
+		tm.sizes.On("List").Return(list, nil)
+
+		assert.NoError(t, checkGPUSizeRegion(config.Sizes(), "s-1vcpu-1gb", "nyc1"))
+		assert.NoError(t, checkGPUSizeRegion(config.Sizes(), "gpu-h100x1-80gb", "tor1"))
+		assert.Error(t, checkGPUSizeRegion(config.Sizes(), "gpu-h100x1-80gb", "nyc1"))
+		assert.Error(t, checkGPUSizeRegion(config.Sizes(), "gpu-missing", "tor1"))
+	})
+}
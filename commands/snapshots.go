@@ -0,0 +1,365 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/cobra"
+)
+
+const (
+	snapshotKeep    = "keep"
+	snapshotDelete  = "delete"
+	snapshotDeleted = "deleted"
+	snapshotFailed  = "failed"
+)
+
+// defaultSnapshotNameTemplate names snapshots taken by snapshot-rotate.
+const defaultSnapshotNameTemplate = "{{.DropletName}}-{{.Date}}"
+
+// snapshotNameData are the fields available to snapshot name templates.
+type snapshotNameData struct {
+	DropletID   int
+	DropletName string
+	Date        string
+	Time        string
+	Timestamp   string
+}
+
+// Placeholders for the time dependent template fields, used to turn a name
+// template into a pattern matching every name it can produce.
+const (
+	datePlaceholder      = "\x00date\x00"
+	timePlaceholder      = "\x00time\x00"
+	timestampPlaceholder = "\x00timestamp\x00"
+)
+
+// snapshotNow returns the current time. It is replaced in tests.
+var snapshotNow = time.Now
+
+// Snapshot creates the snapshot command.
+func Snapshot() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "snapshot",
+			Short: "snapshot commands",
+			Long:  "snapshot is used to access snapshot commands",
+		},
+		DocCategories: []string{"snapshot"},
+		IsIndex:       true,
+	}
+
+	cmdSnapshotPrune := CmdBuilder(cmd, RunSnapshotPrune, "prune", "delete old snapshots", Writer,
+		displayerType(&snapshotPlan{}), docCategories("snapshot"))
+	AddStringFlag(cmdSnapshotPrune, doctl.ArgSnapshotResource, "droplet", "Resource type whose snapshots are pruned")
+	AddStringFlag(cmdSnapshotPrune, doctl.ArgOlderThan, "", "Only delete snapshots older than this, e.g. 30d or 12h")
+	AddIntFlag(cmdSnapshotPrune, doctl.ArgKeepLast, 0, "Always keep this many of the newest snapshots of each droplet")
+	AddStringFlag(cmdSnapshotPrune, doctl.ArgNamePrefix, "", "Only consider snapshots whose name has this prefix")
+	AddBoolFlag(cmdSnapshotPrune, doctl.ArgForce, false, "Delete the snapshots instead of only showing the plan")
+
+	return cmd
+}
+
+// snapshotPlanItem is a snapshot and what pruning does with it.
+type snapshotPlanItem struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	DropletID   int       `json:"droplet_id,omitempty"`
+	DropletName string    `json:"droplet_name,omitempty"`
+	Created     time.Time `json:"created_at"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// snapshotPruneOptions select the snapshots to delete.
+type snapshotPruneOptions struct {
+	namePrefix string
+	olderThan  time.Duration
+	keepLast   int
+}
+
+// RunSnapshotPrune deletes snapshots. Unless --force is given, only the plan
+// is shown.
+func RunSnapshotPrune(c *CmdConfig) error {
+	resource, err := c.Doit.GetString(c.NS, doctl.ArgSnapshotResource)
+	if err != nil {
+		return err
+	}
+	if resource != "droplet" {
+		return fmt.Errorf("unsupported snapshot resource %q, only droplet snapshots can be pruned", resource)
+	}
+
+	opts, err := snapshotPruneOpts(c)
+	if err != nil {
+		return err
+	}
+
+	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planDropletSnapshotPrune(c, opts)
+	if err != nil {
+		return err
+	}
+
+	if force {
+		err = executeSnapshotPlan(c.Images(), plan)
+	}
+
+	if derr := c.Display(&snapshotPlan{items: plan}); derr != nil {
+		return derr
+	}
+
+	return err
+}
+
+func snapshotPruneOpts(c *CmdConfig) (*snapshotPruneOptions, error) {
+	opts := &snapshotPruneOptions{}
+
+	olderThan, err := c.Doit.GetString(c.NS, doctl.ArgOlderThan)
+	if err != nil {
+		return nil, err
+	}
+	if olderThan != "" {
+		opts.olderThan, err = parseAge(olderThan)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts.keepLast, err = c.Doit.GetInt(c.NS, doctl.ArgKeepLast)
+	if err != nil {
+		return nil, err
+	}
+	if opts.keepLast < 0 {
+		return nil, fmt.Errorf("%s must not be negative", doctl.ArgKeepLast)
+	}
+
+	if opts.olderThan == 0 && opts.keepLast == 0 {
+		return nil, fmt.Errorf("one of --%s or --%s is required", doctl.ArgOlderThan, doctl.ArgKeepLast)
+	}
+
+	opts.namePrefix, err = c.Doit.GetString(c.NS, doctl.ArgNamePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// parseAge parses a duration which, in addition to the units understood by
+// time.ParseDuration, may use d for days and w for weeks.
+func parseAge(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+
+	return d, nil
+}
+
+// planDropletSnapshotPrune lists droplet snapshots and decides which to
+// delete.
+func planDropletSnapshotPrune(c *CmdConfig, opts *snapshotPruneOptions) ([]*snapshotPlanItem, error) {
+	images, err := c.Images().ListUser(false)
+	if err != nil {
+		return nil, err
+	}
+
+	droplets, err := c.Droplets().List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := snapshotPlanItems(images, droplets, opts.namePrefix)
+	planSnapshotPrune(items, opts, snapshotNow())
+
+	return items, nil
+}
+
+// snapshotPlanItems builds plan items for the snapshots in images whose name
+// has prefix, recording the droplet in droplets each was taken from.
+func snapshotPlanItems(images do.Images, droplets do.Droplets, prefix string) []*snapshotPlanItem {
+	owners := map[int]do.Droplet{}
+	for _, d := range droplets {
+		for _, id := range d.SnapshotIDs {
+			owners[id] = d
+		}
+	}
+
+	var items []*snapshotPlanItem
+	for _, i := range images {
+		if i.Type != "snapshot" || !strings.HasPrefix(i.Name, prefix) {
+			continue
+		}
+
+		item := &snapshotPlanItem{ID: i.ID, Name: i.Name}
+		if created, err := time.Parse(time.RFC3339, i.Created); err == nil {
+			item.Created = created
+		}
+		if d, ok := owners[i.ID]; ok {
+			item.DropletID = d.ID
+			item.DropletName = d.Name
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// planSnapshotPrune sorts items by droplet, newest first, and sets the status
+// of each. Snapshots whose creation time is unknown are always kept.
+func planSnapshotPrune(items []*snapshotPlanItem, opts *snapshotPruneOptions, now time.Time) {
+	sort.Stable(snapshotsByDroplet(items))
+
+	seen := map[int]int{}
+	for _, item := range items {
+		seen[item.DropletID]++
+		item.Status = snapshotKeep
+
+		if seen[item.DropletID] <= opts.keepLast || item.Created.IsZero() {
+			continue
+		}
+		if opts.olderThan > 0 && now.Sub(item.Created) < opts.olderThan {
+			continue
+		}
+
+		item.Status = snapshotDelete
+	}
+}
+
+type snapshotsByDroplet []*snapshotPlanItem
+
+func (s snapshotsByDroplet) Len() int      { return len(s) }
+func (s snapshotsByDroplet) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s snapshotsByDroplet) Less(i, j int) bool {
+	if s[i].DropletID != s[j].DropletID {
+		return s[i].DropletID < s[j].DropletID
+	}
+	return s[i].Created.After(s[j].Created)
+}
+
+// executeSnapshotPlan deletes the snapshots planned for deletion.
+func executeSnapshotPlan(is do.ImagesService, plan []*snapshotPlanItem) error {
+	failed := 0
+	for _, item := range plan {
+		if item.Status != snapshotDelete {
+			continue
+		}
+
+		if err := is.Delete(item.ID); err != nil {
+			item.Status = snapshotFailed
+			item.Error = err.Error()
+			failed++
+			continue
+		}
+
+		item.Status = snapshotDeleted
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d snapshots", failed)
+	}
+
+	return nil
+}
+
+// snapshotName renders the name template for a snapshot of d taken at now.
+func snapshotName(tmpl *template.Template, d do.Droplet, now time.Time) (string, error) {
+	return renderSnapshotName(tmpl, snapshotNameData{
+		DropletID:   d.ID,
+		DropletName: d.Name,
+		Date:        now.Format("2006-01-02"),
+		Time:        now.Format("150405"),
+		Timestamp:   strconv.FormatInt(now.Unix(), 10),
+	})
+}
+
+// snapshotNamePattern returns a pattern matching every name the template
+// produces for d.
+func snapshotNamePattern(tmpl *template.Template, d do.Droplet) (*regexp.Regexp, error) {
+	name, err := renderSnapshotName(tmpl, snapshotNameData{
+		DropletID:   d.ID,
+		DropletName: d.Name,
+		Date:        datePlaceholder,
+		Time:        timePlaceholder,
+		Timestamp:   timestampPlaceholder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := strings.NewReplacer(
+		datePlaceholder, `\d{4}-\d{2}-\d{2}`,
+		timePlaceholder, `\d{6}`,
+		timestampPlaceholder, `\d+`,
+	).Replace(regexp.QuoteMeta(name))
+
+	return regexp.Compile("^" + pattern + "$")
+}
+
+func renderSnapshotName(tmpl *template.Template, data snapshotNameData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid name template: %v", err)
+	}
+
+	return buf.String(), nil
+}
+
+// rotatedSnapshots returns plan items for the snapshots of d in images whose
+// names were produced by the name template.
+func rotatedSnapshots(tmpl *template.Template, d do.Droplet, images do.Images) ([]*snapshotPlanItem, error) {
+	re, err := snapshotNamePattern(tmpl, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*snapshotPlanItem
+	for _, item := range snapshotPlanItems(images, do.Droplets{d}, "") {
+		if item.DropletID == d.ID && re.MatchString(item.Name) {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+var snapshotTestNow = time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+
+func testSnapshot(id int, name string, age time.Duration) do.Image {
+	return do.Image{Image: &godo.Image{
+		ID:      id,
+		Name:    name,
+		Type:    "snapshot",
+		Created: snapshotTestNow.Add(-age).Format(time.RFC3339),
+	}}
+}
+
+func withSnapshotNow(fn func()) {
+	ogNow := snapshotNow
+	snapshotNow = func() time.Time { return snapshotTestNow }
+	defer func() { snapshotNow = ogNow }()
+
+	fn()
+}
+
+func TestSnapshotCommand(t *testing.T) {
+	cmd := Snapshot()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "prune")
+}
+
+func TestParseAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseAge(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	for _, in := range []string{"d", "-3d", "soon"} {
+		_, err := parseAge(in)
+		assert.Error(t, err)
+	}
+}
+
+func TestPlanSnapshotPrune(t *testing.T) {
+	day := 24 * time.Hour
+	items := []*snapshotPlanItem{
+		{ID: 1, DropletID: 7, Created: snapshotTestNow.Add(-40 * day)},
+		{ID: 2, DropletID: 7, Created: snapshotTestNow.Add(-1 * day)},
+		{ID: 3, DropletID: 7, Created: snapshotTestNow.Add(-35 * day)},
+		{ID: 4, DropletID: 7, Created: snapshotTestNow.Add(-50 * day)},
+		{ID: 5, Created: snapshotTestNow.Add(-60 * day)},
+		{ID: 6},
+	}
+
+	planSnapshotPrune(items, &snapshotPruneOptions{olderThan: 30 * day, keepLast: 2}, snapshotTestNow)
+
+	got := map[int]string{}
+	var order []int
+	for _, item := range items {
+		got[item.ID] = item.Status
+		order = append(order, item.ID)
+	}
+
+	assert.Equal(t, []int{5, 6, 2, 3, 1, 4}, order)
+	assert.Equal(t, map[int]string{
+		1: snapshotDelete,
+		2: snapshotKeep,
+		3: snapshotKeep,
+		4: snapshotDelete,
+		5: snapshotKeep,
+		6: snapshotKeep,
+	}, got)
+}
+
+func TestSnapshotPruneDryRun(t *testing.T) {
+	day := 24 * time.Hour
+	images := do.Images{
+		testSnapshot(10, "nightly-1", 40*day),
+		testSnapshot(11, "nightly-2", 1*day),
+		testSnapshot(12, "manual", 90*day),
+		{Image: &godo.Image{ID: 13, Name: "nightly-backup", Type: "backup"}},
+	}
+
+	withSnapshotNow(func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.images.On("ListUser", false).Return(images, nil)
+			tm.droplets.On("List").Return(do.Droplets{}, nil)
+
+			config.Doit.Set(config.NS, doctl.ArgSnapshotResource, "droplet")
+			config.Doit.Set(config.NS, doctl.ArgOlderThan, "30d")
+			config.Doit.Set(config.NS, doctl.ArgNamePrefix, "nightly-")
+
+			err := RunSnapshotPrune(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestSnapshotPruneForce(t *testing.T) {
+	day := 24 * time.Hour
+	images := do.Images{
+		testSnapshot(10, "nightly-1", 40*day),
+		testSnapshot(11, "nightly-2", 35*day),
+		testSnapshot(12, "nightly-3", 1*day),
+	}
+	owner := do.Droplet{Droplet: &godo.Droplet{ID: 7, Name: "web", SnapshotIDs: []int{10, 11, 12}}}
+
+	withSnapshotNow(func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.images.On("ListUser", false).Return(images, nil)
+			tm.droplets.On("List").Return(do.Droplets{owner}, nil)
+			tm.images.On("Delete", 10).Return(nil)
+
+			config.Doit.Set(config.NS, doctl.ArgSnapshotResource, "droplet")
+			config.Doit.Set(config.NS, doctl.ArgKeepLast, 2)
+			config.Doit.Set(config.NS, doctl.ArgForce, true)
+
+			err := RunSnapshotPrune(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestSnapshotPruneRequiresFilter(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgSnapshotResource, "droplet")
+
+		err := RunSnapshotPrune(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestSnapshotPruneVolumeUnsupported(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgSnapshotResource, "volume")
+		config.Doit.Set(config.NS, doctl.ArgKeepLast, 1)
+
+		err := RunSnapshotPrune(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestSnapshotNamePattern(t *testing.T) {
+	d := do.Droplet{Droplet: &godo.Droplet{ID: 7, Name: "web.1"}}
+
+	tmpl := template.Must(template.New("name").Parse("nightly-{{.DropletName}}-{{.Date}}"))
+
+	name, err := snapshotName(tmpl, d, snapshotTestNow)
+	assert.NoError(t, err)
+	assert.Equal(t, "nightly-web.1-2016-10-01", name)
+
+	re, err := snapshotNamePattern(tmpl, d)
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString(name))
+	assert.True(t, re.MatchString("nightly-web.1-2015-01-31"))
+	assert.False(t, re.MatchString("nightly-webx1-2015-01-31"))
+	assert.False(t, re.MatchString("nightly-web.1-manual"))
+}
+
+func TestDropletSnapshotRotate(t *testing.T) {
+	day := 24 * time.Hour
+	images := do.Images{
+		testSnapshot(10, "web-2016-09-28", 3*day),
+		testSnapshot(11, "web-2016-09-29", 2*day),
+		testSnapshot(12, "web-2016-09-30", 1*day),
+		testSnapshot(13, "web-2016-10-01", 0),
+		testSnapshot(14, "web-before-upgrade", 10*day),
+	}
+	before := do.Droplet{Droplet: &godo.Droplet{ID: 7, Name: "web", SnapshotIDs: []int{10, 11, 12, 14}}}
+	after := do.Droplet{Droplet: &godo.Droplet{ID: 7, Name: "web", SnapshotIDs: []int{10, 11, 12, 13, 14}}}
+	action := &do.Action{Action: &godo.Action{ID: 99, Status: "completed"}}
+
+	withSnapshotNow(func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("ListByTag", "web").Return(do.Droplets{before}, nil)
+			tm.dropletActions.On("Snapshot", 7, "web-2016-10-01").Return(action, nil)
+			tm.actions.On("Get", 99).Return(action, nil)
+			tm.images.On("ListUser", false).Return(images, nil)
+			tm.droplets.On("Get", 7).Return(&after, nil)
+			tm.images.On("Delete", 10).Return(nil)
+			tm.images.On("Delete", 11).Return(nil)
+
+			config.Args = append(config.Args, "web")
+			config.Doit.Set(config.NS, doctl.ArgKeep, 2)
+			config.Doit.Set(config.NS, doctl.ArgNameTemplate, defaultSnapshotNameTemplate)
+
+			err := RunDropletSnapshotRotate(config)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestDropletSnapshotRotateSnapshotFails(t *testing.T) {
+	d := do.Droplet{Droplet: &godo.Droplet{ID: 7, Name: "web"}}
+	action := &do.Action{Action: &godo.Action{ID: 99, Status: "errored"}}
+
+	withSnapshotNow(func() {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.On("Get", 7).Return(&d, nil)
+			tm.dropletActions.On("Snapshot", 7, "web-2016-10-01").Return(action, nil)
+			tm.actions.On("Get", 99).Return(action, nil)
+
+			config.Args = append(config.Args, "7")
+			config.Doit.Set(config.NS, doctl.ArgKeep, 2)
+			config.Doit.Set(config.NS, doctl.ArgNameTemplate, defaultSnapshotNameTemplate)
+
+			err := RunDropletSnapshotRotate(config)
+			assert.Error(t, err)
+		})
+	})
+}
@@ -15,6 +15,7 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"os/user"
 	"path/filepath"
 	"regexp"
@@ -36,6 +37,7 @@ func SSH(parent *Command) *Command {
 	checkErr(err)
 
 	path := filepath.Join(usr.HomeDir, ".ssh", "id_rsa")
+	knownHosts := filepath.Join(usr.HomeDir, ".doctl_known_hosts")
 
 	cmdSSH := CmdBuilder(parent, RunSSH, "ssh <droplet-id | host>", "ssh to droplet", Writer,
 		docCategories("droplet"))
@@ -43,6 +45,12 @@ func SSH(parent *Command) *Command {
 	AddStringFlag(cmdSSH, doctl.ArgsSSHKeyPath, path, "path to private ssh key")
 	AddIntFlag(cmdSSH, doctl.ArgsSSHPort, 22, "port sshd is running on")
 	AddBoolFlag(cmdSSH, doctl.ArgsSSHAgentForwarding, false, "enable ssh agent forwarding")
+	AddBoolFlag(cmdSSH, doctl.ArgsSSHPrivateIP, false, "ssh to the droplet's private ip")
+	AddStringFlag(cmdSSH, doctl.ArgsSSHJump, "", "droplet or [user@]host[:port] to use as a jump host")
+	AddStringSliceFlagP(cmdSSH, doctl.ArgsSSHLocalForward, "L", []string{}, "forward [bind_address:]port:host:hostport to the droplet")
+	AddStringSliceFlagP(cmdSSH, doctl.ArgsSSHRemoteForward, "R", []string{}, "forward [bind_address:]port:host:hostport from the droplet")
+	AddStringFlag(cmdSSH, doctl.ArgsSSHStrictHostChecking, "", "pin droplet host keys: yes, no or accept-new")
+	AddStringFlag(cmdSSH, doctl.ArgsSSHKnownHostsFile, knownHosts, "known hosts file for pinned droplet host keys")
 
 	return cmdSSH
 }
@@ -80,9 +88,41 @@ func RunSSH(c *CmdConfig) error {
 		return err
 	}
 
+	for _, key := range []string{doctl.ArgsSSHLocalForward, doctl.ArgsSSHRemoteForward} {
+		forwards, err := c.Doit.GetStringSlice(c.NS, key)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range forwards {
+			if _, err := ssh.ParseForward(f); err != nil {
+				return err
+			}
+		}
+		opts[key] = forwards
+	}
+
+	privateIP, err := c.Doit.GetBool(c.NS, doctl.ArgsSSHPrivateIP)
+	if err != nil {
+		return err
+	}
+
+	jump, err := c.Doit.GetString(c.NS, doctl.ArgsSSHJump)
+	if err != nil {
+		return err
+	}
+
 	var droplet *do.Droplet
 
 	ds := c.Droplets()
+	if jump != "" {
+		jump, err = resolveJumpHost(ds, jump)
+		if err != nil {
+			return err
+		}
+	}
+	opts[doctl.ArgsSSHJump] = jump
+
 	if id, err := strconv.Atoi(dropletID); err == nil {
 		// dropletID is an integer
 
@@ -130,7 +170,12 @@ func RunSSH(c *CmdConfig) error {
 		user = defaultSSHUser(droplet)
 	}
 
-	ip, err := droplet.PublicIPv4()
+	var ip string
+	if privateIP {
+		ip, err = droplet.PrivateIPv4()
+	} else {
+		ip, err = droplet.PublicIPv4()
+	}
 	if err != nil {
 		return err
 	}
@@ -139,10 +184,100 @@ func RunSSH(c *CmdConfig) error {
 		return errors.New("could not find droplet address")
 	}
 
+	err = pinDropletHostKey(c, ds, droplet, opts)
+	if err != nil {
+		return err
+	}
+
 	runner := c.Doit.SSH(user, ip, keyPath, port, opts)
 	return runner.Run()
 }
 
+// pinDropletHostKey sets up host key checking against a known hosts file
+// where keys are pinned per droplet id. Pins made before the droplet's
+// latest rebuild are dropped.
+func pinDropletHostKey(c *CmdConfig, ds do.DropletsService, droplet *do.Droplet, opts ssh.Options) error {
+	mode, err := c.Doit.GetString(c.NS, doctl.ArgsSSHStrictHostChecking)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case "":
+		return nil
+	case "yes", "no", "accept-new":
+	default:
+		return fmt.Errorf("invalid host key checking mode %q, expected yes, no or accept-new", mode)
+	}
+
+	path, err := c.Doit.GetString(c.NS, doctl.ArgsSSHKnownHostsFile)
+	if err != nil {
+		return err
+	}
+
+	actions, err := ds.Actions(droplet.ID)
+	if err != nil {
+		return err
+	}
+
+	rebuildID := 0
+	for _, a := range actions {
+		if a.Type == "rebuild" && a.Status == "completed" && a.ID > rebuildID {
+			rebuildID = a.ID
+		}
+	}
+
+	err = ssh.SyncDropletHost(path, droplet.ID, rebuildID)
+	if err != nil {
+		return err
+	}
+
+	opts[doctl.ArgsSSHStrictHostChecking] = mode
+	opts[doctl.ArgsSSHKnownHostsFile] = path
+	opts[doctl.ArgsSSHHostKeyAlias] = ssh.DropletHostAlias(droplet.ID)
+
+	return nil
+}
+
+// resolveJumpHost converts a droplet id or name into the droplet's public
+// address. Anything else is used as an ssh host as is.
+func resolveJumpHost(ds do.DropletsService, jump string) (string, error) {
+	shi := extractHostInfo(jump)
+
+	droplets, err := ds.List()
+	if err != nil {
+		return "", err
+	}
+
+	for _, d := range droplets {
+		if d.Name != shi.host && strconv.Itoa(d.ID) != shi.host {
+			continue
+		}
+
+		ip, err := d.PublicIPv4()
+		if err != nil {
+			return "", err
+		}
+
+		if ip == "" {
+			return "", errors.New("could not find jump host address")
+		}
+
+		user := shi.user
+		if user == "" {
+			user = defaultSSHUser(&d)
+		}
+
+		host := user + "@" + ip
+		if shi.port != "" {
+			host += ":" + shi.port
+		}
+		return host, nil
+	}
+
+	return jump, nil
+}
+
 func defaultSSHUser(droplet *do.Droplet) string {
 	slug := strings.ToLower(droplet.Image.Slug)
 	if strings.Contains(slug, "coreos") {
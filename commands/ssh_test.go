@@ -14,13 +14,17 @@ limitations under the License.
 package commands
 
 import (
+	"io/ioutil"
+	"os"
 	"strconv"
 	"testing"
 
 	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/doctl/pkg/runner"
 	"github.com/digitalocean/doctl/pkg/runner/mocks"
 	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/digitalocean/godo"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -158,3 +162,143 @@ func Test_extractHostInfo(t *testing.T) {
 		assert.Equal(t, c.e, i)
 	}
 }
+
+func TestSSH_PrivateIPThroughJumpDroplet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		rm := &mocks.Runner{}
+		rm.On("Run").Return(nil)
+
+		tc := config.Doit.(*TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, "172.16.1.2", host)
+			assert.Equal(t, "root@8.8.8.8", opts[doctl.ArgsSSHJump])
+			return rm
+		}
+
+		bastion := do.Droplet{Droplet: &godo.Droplet{
+			ID:    5,
+			Name:  "bastion",
+			Image: &godo.Image{Slug: "ubuntu-16-04-x64"},
+			Networks: &godo.Networks{
+				V4: []godo.NetworkV4{{IPAddress: "8.8.8.8", Type: "public"}},
+			},
+		}}
+		tm.droplets.On("List").Return(do.Droplets{bastion}, nil)
+		tm.droplets.On("Get", testPrivateDroplet.ID).Return(&testPrivateDroplet, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHPrivateIP, true)
+		config.Doit.Set(config.NS, doctl.ArgsSSHJump, "bastion")
+		config.Args = append(config.Args, strconv.Itoa(testPrivateDroplet.ID))
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSSH_JumpHost(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		rm := &mocks.Runner{}
+		rm.On("Run").Return(nil)
+
+		tc := config.Doit.(*TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, "ops@jump.example.com:2222", opts[doctl.ArgsSSHJump])
+			return rm
+		}
+
+		tm.droplets.On("List").Return(testDropletList, nil)
+		tm.droplets.On("Get", testDroplet.ID).Return(&testDroplet, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHJump, "ops@jump.example.com:2222")
+		config.Args = append(config.Args, strconv.Itoa(testDroplet.ID))
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSSH_StrictHostChecking(t *testing.T) {
+	f, err := ioutil.TempFile("", "known_hosts")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("# doctl-rebuild droplet-1 0\ndroplet-1 ssh-rsa AAAA\ndroplet-2 ssh-rsa BBBB\n")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		rm := &mocks.Runner{}
+		rm.On("Run").Return(nil)
+
+		tc := config.Doit.(*TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, "accept-new", opts[doctl.ArgsSSHStrictHostChecking])
+			assert.Equal(t, f.Name(), opts[doctl.ArgsSSHKnownHostsFile])
+			assert.Equal(t, "droplet-1", opts[doctl.ArgsSSHHostKeyAlias])
+			return rm
+		}
+
+		actions := do.Actions{
+			{Action: &godo.Action{ID: 7, Type: "rebuild", Status: "completed"}},
+			{Action: &godo.Action{ID: 9, Type: "rebuild", Status: "in-progress"}},
+		}
+		tm.droplets.On("Get", testDroplet.ID).Return(&testDroplet, nil)
+		tm.droplets.On("Actions", testDroplet.ID).Return(actions, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHStrictHostChecking, "accept-new")
+		config.Doit.Set(config.NS, doctl.ArgsSSHKnownHostsFile, f.Name())
+		config.Args = append(config.Args, strconv.Itoa(testDroplet.ID))
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+
+		b, err := ioutil.ReadFile(f.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, "droplet-2 ssh-rsa BBBB\n# doctl-rebuild droplet-1 7\n", string(b))
+	})
+}
+
+func TestSSH_InvalidStrictHostChecking(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.On("Get", testDroplet.ID).Return(&testDroplet, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHStrictHostChecking, "maybe")
+		config.Args = append(config.Args, strconv.Itoa(testDroplet.ID))
+
+		err := RunSSH(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestSSH_Forwards(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		rm := &mocks.Runner{}
+		rm.On("Run").Return(nil)
+
+		tc := config.Doit.(*TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, []string{"5432:10.0.0.5:5432"}, opts[doctl.ArgsSSHLocalForward])
+			assert.Equal(t, []string{"0.0.0.0:8080:localhost:3000"}, opts[doctl.ArgsSSHRemoteForward])
+			return rm
+		}
+
+		tm.droplets.On("Get", testDroplet.ID).Return(&testDroplet, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHLocalForward, []string{"5432:10.0.0.5:5432"})
+		config.Doit.Set(config.NS, doctl.ArgsSSHRemoteForward, []string{"0.0.0.0:8080:localhost:3000"})
+		config.Args = append(config.Args, strconv.Itoa(testDroplet.ID))
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSSH_InvalidForward(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgsSSHLocalForward, []string{"5432"})
+		config.Args = append(config.Args, strconv.Itoa(testDroplet.ID))
+
+		err := RunSSH(config)
+		assert.Error(t, err)
+	})
+}
@@ -14,7 +14,13 @@ limitations under the License.
 package commands
 
 import (
+	"crypto/md5"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
@@ -203,3 +209,118 @@ func RunKeyUpdate(c *CmdConfig) error {
 	item := &key{keys: do.SSHKeys{*k}}
 	return c.Display(item)
 }
+
+var fingerprintRe = regexp.MustCompile(`^([0-9a-fA-F]{2}:){15}[0-9a-fA-F]{2}$`)
+
+// resolveSSHKeys replaces key names and public key file paths with
+// fingerprints. Public keys that are not yet registered are uploaded.
+func resolveSSHKeys(ks do.KeysService, keys []godo.DropletCreateSSHKey) ([]godo.DropletCreateSSHKey, error) {
+	var existing do.SSHKeys
+	listed := false
+	list := func() (do.SSHKeys, error) {
+		if listed {
+			return existing, nil
+		}
+
+		var err error
+		existing, err = ks.List()
+		if err != nil {
+			return nil, err
+		}
+		listed = true
+		return existing, nil
+	}
+
+	resolved := []godo.DropletCreateSSHKey{}
+	for _, k := range keys {
+		if k.ID > 0 || fingerprintRe.MatchString(k.Fingerprint) {
+			resolved = append(resolved, k)
+			continue
+		}
+
+		all, err := list()
+		if err != nil {
+			return nil, err
+		}
+
+		var fingerprint string
+		if isPublicKeyPath(k.Fingerprint) {
+			fingerprint, err = uploadPublicKey(ks, all, k.Fingerprint)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			for _, existingKey := range all {
+				if existingKey.Name == k.Fingerprint {
+					fingerprint = existingKey.Fingerprint
+					break
+				}
+			}
+
+			if fingerprint == "" {
+				return nil, fmt.Errorf("ssh key %q could not be found", k.Fingerprint)
+			}
+		}
+
+		resolved = append(resolved, godo.DropletCreateSSHKey{Fingerprint: fingerprint})
+	}
+
+	return resolved, nil
+}
+
+func isPublicKeyPath(s string) bool {
+	if strings.HasSuffix(s, ".pub") {
+		return true
+	}
+
+	fi, err := os.Stat(s)
+	return err == nil && !fi.IsDir()
+}
+
+// uploadPublicKey returns the fingerprint of the public key at path,
+// creating the key if it is not in existing.
+func uploadPublicKey(ks do.KeysService, existing do.SSHKeys, path string) (string, error) {
+	keyFile, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey(keyFile)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := md5Fingerprint(pub)
+	for _, k := range existing {
+		if k.Fingerprint == fingerprint {
+			return fingerprint, nil
+		}
+	}
+
+	name := comment
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), ".pub")
+	}
+
+	kcr := &godo.KeyCreateRequest{
+		Name:      name,
+		PublicKey: string(keyFile),
+	}
+
+	k, err := ks.Create(kcr)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Fingerprint, nil
+}
+
+func md5Fingerprint(pub ssh.PublicKey) string {
+	sum := md5.Sum(pub.Marshal())
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(parts, ":")
+}
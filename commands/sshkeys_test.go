@@ -153,3 +153,37 @@ func TestSSHPublicKeyImportWithName(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestResolveSSHKeys(t *testing.T) {
+	pubkey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCn6eZ8ve0ha04rPRZuoPXK1AQ/h21qslWCzoDcOciXn5OcyafkZw+31k/afaBTeW62D8fXd8e/1xWbFfp/2GqmslYpNCTPrtpNhsE8I0yKjJ8FxX9FfsCOu/Sv83dWgSpiT7pNWVKarZjW9KdKKRQljq1i+H5pX3r5Q9I1v+66mYTe7qsKGas9KWy0vkGoNSqmTCl+d+Y0286chtqBqBjSCUCI8oLKPnJB86Lj344tFGmzDIsJKXMVHTL0dF8n3u6iWN4qiRU+JvkoIkI3v0JvyZXxhR2uPIS1yUAY2GC+2O5mfxydJQzBdtag5Uw8Y7H5yYR1gar/h16bAy5XzRvp testkey"
+	path := filepath.Join(os.TempDir(), "resolve.pub")
+	err := ioutil.WriteFile(path, []byte(pubkey), 0600)
+	assert.NoError(t, err)
+	defer os.Remove(path)
+
+	fingerprint := "00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff"
+	named := do.SSHKey{Key: &godo.Key{ID: 2, Name: "laptop", Fingerprint: fingerprint}}
+	uploaded := do.SSHKey{Key: &godo.Key{ID: 3, Name: "testkey", Fingerprint: "uploaded"}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.keys.On("List").Return(do.SSHKeys{named}, nil)
+		kcr := &godo.KeyCreateRequest{Name: "testkey", PublicKey: pubkey}
+		tm.keys.On("Create", kcr).Return(&uploaded, nil)
+
+		in := []godo.DropletCreateSSHKey{{ID: 1}, {Fingerprint: fingerprint}, {Fingerprint: "laptop"}, {Fingerprint: path}}
+		got, err := resolveSSHKeys(config.Keys(), in)
+		assert.NoError(t, err)
+
+		expected := []godo.DropletCreateSSHKey{{ID: 1}, {Fingerprint: fingerprint}, {Fingerprint: fingerprint}, {Fingerprint: "uploaded"}}
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestResolveSSHKeysMissingName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.keys.On("List").Return(testKeyList, nil)
+
+		_, err := resolveSSHKeys(config.Keys(), []godo.DropletCreateSSHKey{{Fingerprint: "missing"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/viper"
+)
+
+// colorDefault is the SGR code for the terminal's default foreground color.
+const colorDefault color.Attribute = 39
+
+// themeColors are the color names which can be used in the theme
+// configuration. Every code has two digits, so colored cells in a column all
+// carry the same number of escape bytes and tabwriter alignment is kept.
+var themeColors = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+	"default": colorDefault,
+}
+
+// colorOutput returns the writer to send colored output for w to, and
+// whether w is a terminal that should get color at all. Escape codes go
+// through colorable so Windows consoles render them. It is a variable so
+// tests can treat a buffer as a terminal.
+var colorOutput = func(w io.Writer) (io.Writer, bool) {
+	f, ok := w.(*os.File)
+	if !ok || !isatty.IsTerminal(f.Fd()) {
+		return w, false
+	}
+
+	switch f {
+	case os.Stdout:
+		return colorable.NewColorableStdout(), true
+	case os.Stderr:
+		return colorable.NewColorableStderr(), true
+	}
+
+	return w, false
+}
+
+// defaultTheme is the color of each status when no theme is configured.
+var defaultTheme = map[string]string{
+	"active":      "green",
+	"completed":   "green",
+	"created":     "green",
+	"deleted":     "green",
+	"ok":          "green",
+	"new":         "yellow",
+	"in-progress": "yellow",
+	"warning":     "yellow",
+	"skipped":     "yellow",
+	"delete":      "yellow",
+	"errored":     "red",
+	"failed":      "red",
+	"locked":      "red",
+}
+
+// themedColumns are the columns whose values are colored by status.
+var themedColumns = map[string]bool{
+	"Status": true,
+}
+
+// statusTheme maps status values to colors.
+type statusTheme map[string]color.Attribute
+
+// loadTheme builds the status theme from the defaults and the "theme" map in
+// the config file, e.g.
+//
+//	theme:
+//	  active: cyan
+//	  off: red
+func loadTheme() statusTheme {
+	t := statusTheme{}
+	for status, name := range defaultTheme {
+		t[status] = themeColors[name]
+	}
+
+	for status, name := range viper.GetStringMapString("theme") {
+		if code, ok := themeColors[strings.ToLower(name)]; ok {
+			t[strings.ToLower(status)] = code
+		}
+	}
+
+	return t
+}
+
+// colorize wraps v in the color configured for its value.
+func (t statusTheme) colorize(v interface{}) string {
+	s := fmt.Sprint(v)
+
+	code, ok := t[strings.ToLower(s)]
+	if !ok {
+		code = colorDefault
+	}
+
+	return color.New(code).SprintFunc()(s)
+}
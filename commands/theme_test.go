@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func withColor() func() {
+	ogNoColor := color.NoColor
+	color.NoColor = false
+	return func() { color.NoColor = ogNoColor }
+}
+
+func TestStatusThemeColorize(t *testing.T) {
+	defer withColor()()
+	theme := loadTheme()
+
+	assert.Equal(t, "\x1b[32mactive\x1b[0m", theme.colorize("active"))
+	assert.Equal(t, "\x1b[31merrored\x1b[0m", theme.colorize("errored"))
+	assert.Equal(t, "\x1b[39moff\x1b[0m", theme.colorize("off"))
+}
+
+func TestStatusThemeConfig(t *testing.T) {
+	defer withColor()()
+	viper.Set("theme", map[string]string{"active": "cyan", "off": "Magenta", "new": "plaid"})
+	defer viper.Set("theme", nil)
+
+	theme := loadTheme()
+
+	assert.Equal(t, "\x1b[36mactive\x1b[0m", theme.colorize("active"))
+	assert.Equal(t, "\x1b[35moff\x1b[0m", theme.colorize("off"))
+	assert.Equal(t, "\x1b[33mnew\x1b[0m", theme.colorize("new"))
+}
+
+func TestDisplayColoredStatus(t *testing.T) {
+	ogNoColor, ogColorOutput := color.NoColor, colorOutput
+	defer func() { color.NoColor, colorOutput = ogNoColor, ogColorOutput }()
+	colorOutput = func(w io.Writer) (io.Writer, bool) { return w, true }
+
+	actions := do.Actions{
+		{Action: &godo.Action{ID: 1, Status: "completed"}},
+		{Action: &godo.Action{ID: 22, Status: "errored"}},
+	}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgFormat, "ID,Status")
+
+		color.NoColor = false
+		var buf bytes.Buffer
+		config.Out = &buf
+		assert.NoError(t, config.Display(&action{actions: actions}))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, []string{
+			"ID\t\x1b[39mStatus\x1b[0m",
+			"1\t\x1b[32mcompleted\x1b[0m",
+			"22\t\x1b[31merrored\x1b[0m",
+		}, lines)
+
+		color.NoColor = true
+		buf.Reset()
+		assert.NoError(t, config.Display(&action{actions: actions}))
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+}
+
+func TestDisplayNoColorWhenPiped(t *testing.T) {
+	defer withColor()()
+
+	actions := do.Actions{{Action: &godo.Action{ID: 1, Status: "completed"}}}
+
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var buf bytes.Buffer
+		config.Out = &buf
+		assert.NoError(t, config.Display(&action{actions: actions}))
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+
+	_, terminal := colorOutput(&bytes.Buffer{})
+	assert.False(t, terminal)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Validate creates the validate command.
+func Validate() *Command {
+	// validate is built without CmdBuilder so the client is only created
+	// when slugs are looked up.
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:   "validate -- <command...>",
+			Short: "check a doctl command without running it",
+			Long: `validate parses a doctl command's flags and arguments and checks region,
+size and image slugs with read only API calls. Nothing is created or changed.
+Separate the command from validate's own flags with --, e.g.
+
+  doctl validate --offline -- compute droplet create web --size 512mb`,
+			Run: func(cmd *cobra.Command, args []string) {
+				ns := cmdNS(cmd)
+
+				offline, err := doctl.DoitConfig.GetBool(ns, doctl.ArgOffline)
+				checkErr(err, cmd)
+
+				var c *CmdConfig
+				if offline {
+					c = NewCmdConfigWithClient(ns, doctl.DoitConfig, Writer, args, nil)
+				} else {
+					c, err = NewCmdConfig(ns, doctl.DoitConfig, Writer, args)
+					checkErr(err, cmd)
+				}
+
+				checkErr(RunValidate(c, offline))
+			},
+		},
+	}
+	AddBoolFlag(cmd, doctl.ArgOffline, false, "Don't check slugs, so no access token is needed")
+
+	return cmd
+}
+
+// RunValidate checks that c.Args is a runnable doctl command with valid
+// flags, that required flags have values and, unless offline, that region,
+// size and image slugs exist.
+func RunValidate(c *CmdConfig, offline bool) error {
+	args := c.Args
+	if len(args) > 0 && args[0] == DoitCmd.Name() {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	cmd, rest, err := DoitCmd.Find(args)
+	if err != nil {
+		return err
+	}
+	if cmd == DoitCmd.Command || !cmd.Runnable() {
+		return fmt.Errorf("%q is not a doctl command", strings.Join(args, " "))
+	}
+
+	defer resetFlags(DoitCmd.Command)
+	if err := cmd.ParseFlags(rest); err != nil {
+		return fmt.Errorf("%s: %v", cmd.CommandPath(), err)
+	}
+
+	var problems []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if p := validateFlag(c, cmd, f, offline); p != "" {
+			problems = append(problems, p)
+		}
+	})
+
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintf(c.Out, "%s: %s\n", cmd.CommandPath(), p)
+		}
+		return fmt.Errorf("%s is not valid", cmd.CommandPath())
+	}
+
+	fmt.Fprintf(c.Out, "%s is valid\n", cmd.CommandPath())
+	return nil
+}
+
+// validateFlag returns a description of what is wrong with f, or an empty
+// string if nothing is.
+func validateFlag(c *CmdConfig, cmd *cobra.Command, f *pflag.Flag, offline bool) string {
+	key := fmt.Sprintf("%s.%s", cmdNS(cmd), f.Name)
+	value := f.Value.String()
+	if !f.Changed {
+		value = viper.GetString(key)
+	}
+
+	if value == "" {
+		if viper.GetBool(requiredKey(key)) {
+			return fmt.Sprintf("--%s is required", f.Name)
+		}
+		return ""
+	}
+
+	if offline || !f.Changed && value == f.DefValue {
+		return ""
+	}
+
+	var err error
+	switch f.Name {
+	case doctl.ArgRegionSlug:
+		err = validateRegion(c, value)
+	case doctl.ArgSizeSlug:
+		err = validateSize(c, value)
+	case doctl.ArgImage:
+		err = validateImage(c, value)
+	}
+	if err != nil {
+		return fmt.Sprintf("--%s: %v", f.Name, err)
+	}
+
+	return ""
+}
+
+func validateRegion(c *CmdConfig, slug string) error {
+	regions, err := c.Regions().List()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range regions {
+		if r.Slug == slug {
+			if !r.Available {
+				return fmt.Errorf("region %q is not available", slug)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown region %q", slug)
+}
+
+func validateSize(c *CmdConfig, slug string) error {
+	sizes, err := c.Sizes().List()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sizes {
+		if s.Slug == slug {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown size %q", slug)
+}
+
+func validateImage(c *CmdConfig, image string) error {
+	is := c.Images()
+
+	ci, err := resolveCreateImage(is, image)
+	if err != nil {
+		return err
+	}
+
+	if ci.Slug == "" {
+		_, err = is.GetByID(ci.ID)
+	} else {
+		_, err = is.GetBySlug(ci.Slug)
+	}
+	if err != nil {
+		return fmt.Errorf("unknown image %q", image)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunValidate(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		regions := do.Regions{{Region: &godo.Region{Slug: "dev0", Available: true}}}
+		tm.regions.On("List").Return(regions, nil)
+		tm.sizes.On("List").Return(testSizeList, nil)
+		tm.images.On("GetBySlug", "slug").Return(&testImage, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = []string{"doctl", "compute", "droplet", "create", "web",
+			"--region", "dev0", "--size", "small", "--image", "slug"}
+
+		err := RunValidate(config, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "doctl compute droplet create is valid\n", buf.String())
+	})
+}
+
+func TestRunValidateProblems(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.sizes.On("List").Return(testSizeList, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = []string{"compute", "droplet", "create", "web", "--size", "huge"}
+
+		err := RunValidate(config, false)
+		assert.Error(t, err)
+		assert.Contains(t, buf.String(), "--region is required")
+		assert.Contains(t, buf.String(), "--image is required")
+		assert.Contains(t, buf.String(), `--size: unknown size "huge"`)
+	})
+}
+
+func TestRunValidateOffline(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = []string{"compute", "droplet", "create", "web",
+			"--region", "nowhere", "--size", "huge", "--image", "none"}
+
+		err := RunValidate(config, true)
+		assert.NoError(t, err)
+
+		// flags parsed during validation are reset afterwards.
+		cmd, _, err := DoitCmd.Find([]string{"compute", "droplet", "create"})
+		assert.NoError(t, err)
+		assert.False(t, cmd.Flag("region").Changed)
+	})
+}
+
+func TestRunValidateBadCommand(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		for _, args := range [][]string{
+			{},
+			{"compute", "droplet"},
+			{"compute", "droplet", "list", "--bogus"},
+		} {
+			config.Args = args
+			err := RunValidate(config, true)
+			assert.Error(t, err, "%v", args)
+		}
+	})
+}
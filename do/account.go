@@ -48,7 +48,7 @@ func NewAccountService(godoClient *godo.Client) AccountService {
 func (as *accountService) Get() (*Account, error) {
 	godoAccount, _, err := as.client.Account.Get()
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	account := &Account{Account: godoAccount}
@@ -58,7 +58,7 @@ func (as *accountService) Get() (*Account, error) {
 func (as *accountService) RateLimit() (*RateLimit, error) {
 	_, resp, err := as.client.Account.Get()
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	rateLimit := &RateLimit{Rate: &resp.Rate}
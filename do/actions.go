@@ -27,6 +27,7 @@ type Actions []Action
 // ActionsService is an interface for interacting with DigitalOcean's action api.
 type ActionsService interface {
 	List() (Actions, error)
+	Each(func(Action) error) error
 	Get(int) (*Action, error)
 }
 
@@ -60,7 +61,7 @@ func (as *actionsService) List() (Actions, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Actions, len(si))
@@ -72,10 +73,32 @@ func (as *actionsService) List() (Actions, error) {
 	return list, nil
 }
 
+func (as *actionsService) Each(fn func(Action) error) error {
+	f := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		list, resp, err := as.client.Actions.List(opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		si := make([]interface{}, len(list))
+		for i := range list {
+			si[i] = list[i]
+		}
+
+		return si, resp, err
+	}
+
+	err := EachPage(f, func(item interface{}) error {
+		a := item.(godo.Action)
+		return fn(Action{Action: &a})
+	})
+	return wrapError(err)
+}
+
 func (as *actionsService) Get(id int) (*Action, error) {
 	a, _, err := as.client.Actions.Get(id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
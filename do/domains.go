@@ -14,7 +14,11 @@ limitations under the License.
 
 package do
 
-import "github.com/digitalocean/godo"
+import (
+	"fmt"
+
+	"github.com/digitalocean/godo"
+)
 
 // Domain wraps a godo Domain.
 type Domain struct {
@@ -35,15 +39,21 @@ type DomainRecords []DomainRecord
 // DomainsService is the godo DOmainsService interface.
 type DomainsService interface {
 	List() (Domains, error)
+	Each(func(Domain) error) error
 	Get(string) (*Domain, error)
 	Create(*godo.DomainCreateRequest) (*Domain, error)
 	Delete(string) error
 
 	Records(string) (DomainRecords, error)
+	EachRecord(string, func(DomainRecord) error) error
 	Record(string, int) (*DomainRecord, error)
 	DeleteRecord(string, int) error
 	EditRecord(string, int, *godo.DomainRecordEditRequest) (*DomainRecord, error)
 	CreateRecord(string, *godo.DomainRecordEditRequest) (*DomainRecord, error)
+
+	RecordTTL(string, int) (int, error)
+	EditRecordTTL(string, int, int) error
+	CreateRecordWithTTL(string, *godo.DomainRecordEditRequest, int) (*DomainRecord, error)
 }
 
 type domainsService struct {
@@ -76,7 +86,7 @@ func (ds *domainsService) List() (Domains, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Domains, len(si))
@@ -88,27 +98,76 @@ func (ds *domainsService) List() (Domains, error) {
 	return list, nil
 }
 
+func (ds *domainsService) Each(fn func(Domain) error) error {
+	f := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		list, resp, err := ds.client.Domains.List(opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		si := make([]interface{}, len(list))
+		for i := range list {
+			si[i] = list[i]
+		}
+
+		return si, resp, err
+	}
+
+	err := EachPage(f, func(item interface{}) error {
+		d := item.(godo.Domain)
+		return fn(Domain{Domain: &d})
+	})
+	return wrapError(err)
+}
+
 func (ds *domainsService) Get(name string) (*Domain, error) {
 	d, _, err := ds.client.Domains.Get(name)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Domain{Domain: d}, nil
 }
 
 func (ds *domainsService) Create(dcr *godo.DomainCreateRequest) (*Domain, error) {
+	if dcr.IPAddress == "" {
+		return ds.createEmpty(dcr.Name)
+	}
+
 	d, _, err := ds.client.Domains.Create(dcr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Domain{Domain: d}, nil
 }
 
+// createEmpty creates a zone without an apex A record. godo always sends
+// ip_address, so the request is built here.
+func (ds *domainsService) createEmpty(name string) (*Domain, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+
+	req, err := ds.client.NewRequest("POST", "v2/domains", body)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var root struct {
+		Domain *godo.Domain `json:"domain"`
+	}
+	_, err = ds.client.Do(req, &root)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return &Domain{Domain: root.Domain}, nil
+}
+
 func (ds *domainsService) Delete(name string) error {
 	_, err := ds.client.Domains.Delete(name)
-	return err
+	return wrapError(err)
 }
 
 func (ds *domainsService) Records(name string) (DomainRecords, error) {
@@ -128,7 +187,7 @@ func (ds *domainsService) Records(name string) (DomainRecords, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(DomainRecords, len(si))
@@ -140,10 +199,32 @@ func (ds *domainsService) Records(name string) (DomainRecords, error) {
 	return list, nil
 }
 
+func (ds *domainsService) EachRecord(name string, fn func(DomainRecord) error) error {
+	f := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		list, resp, err := ds.client.Domains.Records(name, opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		si := make([]interface{}, len(list))
+		for i := range list {
+			si[i] = list[i]
+		}
+
+		return si, resp, err
+	}
+
+	err := EachPage(f, func(item interface{}) error {
+		dr := item.(godo.DomainRecord)
+		return fn(DomainRecord{DomainRecord: &dr})
+	})
+	return wrapError(err)
+}
+
 func (ds *domainsService) Record(domain string, id int) (*DomainRecord, error) {
 	dr, _, err := ds.client.Domains.Record(domain, id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &DomainRecord{DomainRecord: dr}, nil
@@ -151,13 +232,13 @@ func (ds *domainsService) Record(domain string, id int) (*DomainRecord, error) {
 
 func (ds *domainsService) DeleteRecord(domain string, id int) error {
 	_, err := ds.client.Domains.DeleteRecord(domain, id)
-	return err
+	return wrapError(err)
 }
 
 func (ds *domainsService) EditRecord(domain string, id int, drer *godo.DomainRecordEditRequest) (*DomainRecord, error) {
 	dr, _, err := ds.client.Domains.EditRecord(domain, id, drer)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &DomainRecord{DomainRecord: dr}, nil
@@ -166,8 +247,71 @@ func (ds *domainsService) EditRecord(domain string, id int, drer *godo.DomainRec
 func (ds *domainsService) CreateRecord(domain string, drer *godo.DomainRecordEditRequest) (*DomainRecord, error) {
 	dr, _, err := ds.client.Domains.CreateRecord(domain, drer)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &DomainRecord{DomainRecord: dr}, nil
 }
+
+// recordTTL is the part of a domain record godo doesn't know about.
+type recordTTL struct {
+	TTL int `json:"ttl"`
+}
+
+// RecordTTL returns the TTL of a record in seconds. godo's DomainRecord has
+// no TTL, so the request is built here.
+func (ds *domainsService) RecordTTL(domain string, id int) (int, error) {
+	path := fmt.Sprintf("v2/domains/%s/records/%d", domain, id)
+	req, err := ds.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	var root struct {
+		DomainRecord recordTTL `json:"domain_record"`
+	}
+	_, err = ds.client.Do(req, &root)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	return root.DomainRecord.TTL, nil
+}
+
+// EditRecordTTL sets the TTL of a record in seconds, leaving the rest of the
+// record as it is.
+func (ds *domainsService) EditRecordTTL(domain string, id, ttl int) error {
+	path := fmt.Sprintf("v2/domains/%s/records/%d", domain, id)
+	req, err := ds.client.NewRequest("PUT", path, &recordTTL{TTL: ttl})
+	if err != nil {
+		return wrapError(err)
+	}
+
+	_, err = ds.client.Do(req, nil)
+	return wrapError(err)
+}
+
+// CreateRecordWithTTL creates a record with a TTL in seconds rather than the
+// zone's default.
+func (ds *domainsService) CreateRecordWithTTL(domain string, drer *godo.DomainRecordEditRequest, ttl int) (*DomainRecord, error) {
+	body := struct {
+		*godo.DomainRecordEditRequest
+		recordTTL
+	}{drer, recordTTL{TTL: ttl}}
+
+	path := fmt.Sprintf("v2/domains/%s/records", domain)
+	req, err := ds.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var root struct {
+		DomainRecord *godo.DomainRecord `json:"domain_record"`
+	}
+	_, err = ds.client.Do(req, &root)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return &DomainRecord{DomainRecord: root.DomainRecord}, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient creates a godo client which sends its requests to h.
+func newTestClient(t *testing.T, h http.HandlerFunc) (*godo.Client, func()) {
+	ts := httptest.NewServer(h)
+
+	client := godo.NewClient(nil)
+	u, err := url.Parse(ts.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = u
+
+	return client, ts.Close
+}
+
+func newTestDomainsService(t *testing.T, h http.HandlerFunc) (DomainsService, func()) {
+	client, done := newTestClient(t, h)
+	return NewDomainsService(client), done
+}
+
+func TestDomainsServiceRecordTTL(t *testing.T) {
+	ds, done := newTestDomainsService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v2/domains/example.com/records/3", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domain_record":{"id":3,"type":"A","ttl":1800}}`))
+	})
+	defer done()
+
+	ttl, err := ds.RecordTTL("example.com", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1800, ttl)
+}
+
+func TestDomainsServiceEditRecordTTL(t *testing.T) {
+	ds, done := newTestDomainsService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/v2/domains/example.com/records/3", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"ttl": float64(60)}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"domain_record":{"id":3,"type":"A","ttl":60}}`))
+	})
+	defer done()
+
+	assert.NoError(t, ds.EditRecordTTL("example.com", 3, 60))
+}
+
+func TestDomainsServiceCreateRecordWithTTL(t *testing.T) {
+	ds, done := newTestDomainsService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v2/domains/example.com/records", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"type": "TXT", "name": "_acme-challenge", "data": "token", "ttl": float64(30)}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"domain_record":{"id":7,"type":"TXT","name":"_acme-challenge","data":"token","ttl":30}}`))
+	})
+	defer done()
+
+	drer := &godo.DomainRecordEditRequest{Type: "TXT", Name: "_acme-challenge", Data: "token"}
+	r, err := ds.CreateRecordWithTTL("example.com", drer, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, r.ID)
+}
+
+func TestDomainsServiceEachRecord(t *testing.T) {
+	var pages []string
+	ds, done := newTestDomainsService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/domains/example.com/records", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case "1":
+			w.Write([]byte(`{"domain_records":[{"id":1},{"id":2}],"links":{"pages":{"next":"http://example.com/?page=2","last":"http://example.com/?page=3"}}}`))
+		case "2":
+			w.Write([]byte(`{"domain_records":[{"id":3},{"id":4}],"links":{"pages":{"next":"http://example.com/?page=3","last":"http://example.com/?page=3"}}}`))
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	})
+	defer done()
+
+	var ids []int
+	err := ds.EachRecord("example.com", func(r DomainRecord) error {
+		ids = append(ids, r.ID)
+		if r.ID == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
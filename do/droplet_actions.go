@@ -55,7 +55,7 @@ func NewDropletActionsService(godoClient *godo.Client) DropletActionsService {
 
 func (das *dropletActionsService) handleActionResponse(a *godo.Action, err error) (*Action, error) {
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
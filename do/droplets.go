@@ -15,7 +15,6 @@ package do
 
 import (
 	"github.com/digitalocean/godo"
-	"github.com/digitalocean/godo/util"
 )
 
 // DropletIPTable is a table of interface IPS.
@@ -51,6 +50,7 @@ type Kernels []Kernel
 type DropletsService interface {
 	List() (Droplets, error)
 	ListByTag(string) (Droplets, error)
+	Each(func(Droplet) error) error
 	Get(int) (*Droplet, error)
 	Create(*godo.DropletCreateRequest, bool) (*Droplet, error)
 	CreateMultiple(*godo.DropletMultiCreateRequest) (Droplets, error)
@@ -93,7 +93,7 @@ func (ds *dropletsService) List() (Droplets, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Droplets, len(si))
@@ -122,7 +122,7 @@ func (ds *dropletsService) ListByTag(tagName string) (Droplets, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Droplets, len(si))
@@ -134,38 +134,52 @@ func (ds *dropletsService) ListByTag(tagName string) (Droplets, error) {
 	return list, nil
 }
 
+func (ds *dropletsService) Each(fn func(Droplet) error) error {
+	f := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		list, resp, err := ds.client.Droplets.List(opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		si := make([]interface{}, len(list))
+		for i := range list {
+			si[i] = list[i]
+		}
+
+		return si, resp, err
+	}
+
+	err := EachPage(f, func(item interface{}) error {
+		d := item.(godo.Droplet)
+		return fn(Droplet{Droplet: &d})
+	})
+	return wrapError(err)
+}
+
 func (ds *dropletsService) Get(id int) (*Droplet, error) {
 	d, _, err := ds.client.Droplets.Get(id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Droplet{Droplet: d}, nil
 }
 
+// Create creates a droplet. With wait, the droplet is fetched again after
+// it has been created so callers start from its current state. Waiting for
+// it to become active is left to the caller, so the wait can be bounded.
 func (ds *dropletsService) Create(dcr *godo.DropletCreateRequest, wait bool) (*Droplet, error) {
-	d, resp, err := ds.client.Droplets.Create(dcr)
+	d, _, err := ds.client.Droplets.Create(dcr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	if wait {
-		var action *godo.LinkAction
-		for _, a := range resp.Links.Actions {
-			if a.Rel == "create" {
-				action = &a
-				break
-			}
-		}
-
-		if action != nil {
-			_ = util.WaitForActive(ds.client, action.HREF)
-			doDroplet, err := ds.Get(d.ID)
-			if err != nil {
-				return nil, err
-			}
-			d = doDroplet.Droplet
+		doDroplet, err := ds.Get(d.ID)
+		if err != nil {
+			return nil, wrapError(err)
 		}
+		d = doDroplet.Droplet
 	}
 
 	return &Droplet{Droplet: d}, nil
@@ -174,7 +188,7 @@ func (ds *dropletsService) Create(dcr *godo.DropletCreateRequest, wait bool) (*D
 func (ds *dropletsService) CreateMultiple(dmcr *godo.DropletMultiCreateRequest) (Droplets, error) {
 	godoDroplets, _, err := ds.client.Droplets.CreateMultiple(dmcr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	var droplets Droplets
@@ -187,12 +201,12 @@ func (ds *dropletsService) CreateMultiple(dmcr *godo.DropletMultiCreateRequest)
 
 func (ds *dropletsService) Delete(id int) error {
 	_, err := ds.client.Droplets.Delete(id)
-	return err
+	return wrapError(err)
 }
 
 func (ds *dropletsService) DeleteByTag(tag string) error {
 	_, err := ds.client.Droplets.DeleteByTag(tag)
-	return err
+	return wrapError(err)
 }
 
 func (ds *dropletsService) Kernels(id int) (Kernels, error) {
@@ -212,7 +226,7 @@ func (ds *dropletsService) Kernels(id int) (Kernels, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Kernels, len(si))
@@ -241,7 +255,7 @@ func (ds *dropletsService) Snapshots(id int) (Images, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Images, len(si))
@@ -270,7 +284,7 @@ func (ds *dropletsService) Backups(id int) (Images, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Images, len(si))
@@ -299,7 +313,7 @@ func (ds *dropletsService) Actions(id int) (Actions, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Actions, len(si))
@@ -314,7 +328,7 @@ func (ds *dropletsService) Actions(id int) (Actions, error) {
 func (ds *dropletsService) Neighbors(id int) (Droplets, error) {
 	list, _, err := ds.client.Droplets.Neighbors(id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	var droplets Droplets
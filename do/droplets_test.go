@@ -0,0 +1,47 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropletsServiceCreateWaitDoesNotPollAction(t *testing.T) {
+	var paths []string
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/droplets":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"droplet":{"id":1,"status":"new"},"links":{"actions":[{"id":5,"rel":"create","href":"http://` + r.Host + `/v2/actions/5"}]}}`))
+		case "GET /v2/droplets/1":
+			w.Write([]byte(`{"droplet":{"id":1,"status":"new"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+
+	ds := NewDropletsService(client)
+	d, err := ds.Create(&godo.DropletCreateRequest{Name: "web"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", d.Status)
+	assert.Equal(t, []string{"POST /v2/droplets", "GET /v2/droplets/1"}, paths)
+}
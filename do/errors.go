@@ -0,0 +1,107 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// NotFoundError is returned when the requested resource does not exist.
+type NotFoundError struct {
+	*godo.ErrorResponse
+}
+
+// UnauthorizedError is returned when the API rejects the access token, or
+// the token lacks the scope required for the request.
+type UnauthorizedError struct {
+	*godo.ErrorResponse
+}
+
+// RateLimitedError is returned when the API rate limit has been exceeded.
+// Reset is the time at which the limit resets, if the API reported it.
+type RateLimitedError struct {
+	*godo.ErrorResponse
+	Reset time.Time
+}
+
+// ValidationError is returned when the API rejects a request as invalid.
+// Field names the offending request attribute when it can be determined
+// from the API message, and is empty otherwise.
+type ValidationError struct {
+	*godo.ErrorResponse
+	Field string
+}
+
+var validationFieldRe = regexp.MustCompile(`^([A-Za-z_]+) (?:is|are|must|can|cannot|has|should|was) `)
+
+// wrapError converts a godo API error into one of the typed errors in this
+// package. Errors which are not API errors, or which do not map to a known
+// kind, are returned unchanged.
+func wrapError(err error) error {
+	er, ok := err.(*godo.ErrorResponse)
+	if !ok || er.Response == nil {
+		return err
+	}
+
+	switch er.Response.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{ErrorResponse: er}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &UnauthorizedError{ErrorResponse: er}
+	case http.StatusTooManyRequests:
+		e := &RateLimitedError{ErrorResponse: er}
+		if reset, err := strconv.ParseInt(er.Response.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+			e.Reset = time.Unix(reset, 0)
+		}
+		return e
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		e := &ValidationError{ErrorResponse: er}
+		if m := validationFieldRe.FindStringSubmatch(er.Message); m != nil {
+			e.Field = strings.ToLower(m[1])
+		}
+		return e
+	}
+
+	return err
+}
+
+// IsNotFound reports whether err is a NotFoundError.
+func IsNotFound(err error) bool {
+	_, ok := err.(*NotFoundError)
+	return ok
+}
+
+// IsUnauthorized reports whether err is an UnauthorizedError.
+func IsUnauthorized(err error) bool {
+	_, ok := err.(*UnauthorizedError)
+	return ok
+}
+
+// IsRateLimited reports whether err is a RateLimitedError.
+func IsRateLimited(err error) bool {
+	_, ok := err.(*RateLimitedError)
+	return ok
+}
+
+// IsValidation reports whether err is a ValidationError.
+func IsValidation(err error) bool {
+	_, ok := err.(*ValidationError)
+	return ok
+}
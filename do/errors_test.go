@@ -0,0 +1,78 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bryanl/godomock"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func apiError(status int, msg string) *godo.ErrorResponse {
+	req, _ := http.NewRequest("GET", "https://api.digitalocean.com/v2/account", nil)
+	return &godo.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Header: http.Header{}, Request: req},
+		Message:  msg,
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	assert.True(t, IsNotFound(wrapError(apiError(404, "not found"))))
+	assert.True(t, IsUnauthorized(wrapError(apiError(401, "unable to authenticate you"))))
+	assert.True(t, IsUnauthorized(wrapError(apiError(403, "forbidden"))))
+	assert.True(t, IsValidation(wrapError(apiError(422, "Name is invalid"))))
+
+	plain := errors.New("boom")
+	assert.Equal(t, plain, wrapError(plain))
+
+	server := apiError(500, "server error")
+	assert.Equal(t, server, wrapError(server))
+}
+
+func TestWrapErrorRateLimited(t *testing.T) {
+	er := apiError(429, "too many requests")
+	er.Response.Header.Set("RateLimit-Reset", "1444931833")
+
+	err := wrapError(er)
+	rl, ok := err.(*RateLimitedError)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1444931833), rl.Reset.Unix())
+	assert.Equal(t, er.Error(), err.Error())
+}
+
+func TestWrapErrorValidationField(t *testing.T) {
+	err := wrapError(apiError(422, "Region is not available"))
+	ve, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "region", ve.Field)
+
+	err = wrapError(apiError(422, "You specified an invalid size."))
+	ve, ok = err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "", ve.Field)
+}
+
+func TestServiceReturnsTypedError(t *testing.T) {
+	gAccountSvc := &godomock.MockAccountService{}
+	gAccountSvc.On("Get").Return(nil, nil, apiError(401, "unable to authenticate you"))
+
+	as := NewAccountService(&godo.Client{Account: gAccountSvc})
+
+	_, err := as.Get()
+	assert.True(t, IsUnauthorized(err))
+}
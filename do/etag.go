@@ -0,0 +1,203 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CachedResponse is a GET response stored by an ETagCache.
+type CachedResponse struct {
+	ETag   string      `json:"etag"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// ETagCache stores responses by request URL.
+type ETagCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, r *CachedResponse)
+}
+
+// MemoryETagCache is an ETagCache which lives as long as the process.
+type MemoryETagCache struct {
+	mu        sync.Mutex
+	responses map[string]*CachedResponse
+}
+
+var _ ETagCache = &MemoryETagCache{}
+
+// NewMemoryETagCache creates a MemoryETagCache.
+func NewMemoryETagCache() *MemoryETagCache {
+	return &MemoryETagCache{responses: map[string]*CachedResponse{}}
+}
+
+// Get returns the response stored for key.
+func (c *MemoryETagCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, ok := c.responses[key]
+	return r, ok
+}
+
+// Set stores a response for key.
+func (c *MemoryETagCache) Set(key string, r *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses[key] = r
+}
+
+// FileETagCache is an ETagCache which stores each response as a file in a
+// directory, so it can be shared by separate doctl processes. Failures to
+// read or write the cache are treated as cache misses.
+type FileETagCache struct {
+	Dir string
+}
+
+var _ ETagCache = &FileETagCache{}
+
+// NewFileETagCache creates a FileETagCache in dir.
+func NewFileETagCache(dir string) *FileETagCache {
+	return &FileETagCache{Dir: dir}
+}
+
+func (c *FileETagCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the response stored for key.
+func (c *FileETagCache) Get(key string) (*CachedResponse, bool) {
+	b, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var r CachedResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, false
+	}
+
+	return &r, true
+}
+
+// Set stores a response for key. Responses describe account resources, so
+// the directory and files are only readable by the current user.
+func (c *FileETagCache) Set(key string, r *CachedResponse) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return
+	}
+
+	ioutil.WriteFile(c.path(key), b, 0600)
+}
+
+// ETagTransport is an http.RoundTripper which remembers the ETag of GET
+// responses and makes later requests for the same URL conditional. When the
+// API answers 304 Not Modified, the cached body is returned as a 200, so
+// callers can't tell the difference, but the request costs less.
+type ETagTransport struct {
+	Transport http.RoundTripper
+	Cache     ETagCache
+}
+
+var _ http.RoundTripper = &ETagTransport{}
+
+// NewETagTransport creates an ETagTransport which sends requests with
+// transport and stores responses in cache.
+func NewETagTransport(transport http.RoundTripper, cache ETagCache) *ETagTransport {
+	return &ETagTransport{Transport: transport, Cache: cache}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ETagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != "GET" || req.Header.Get("If-None-Match") != "" {
+		return t.transport().RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, ok := t.Cache.Get(key)
+	if ok {
+		r := new(http.Request)
+		*r = *req
+		r.Header = make(http.Header, len(req.Header)+1)
+		for k, v := range req.Header {
+			r.Header[k] = v
+		}
+		r.Header.Set("If-None-Match", cached.ETag)
+		req = r
+	}
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ok && resp.StatusCode == http.StatusNotModified:
+		resp.Body.Close()
+
+		header := make(http.Header, len(cached.Header))
+		for k, v := range cached.Header {
+			header[k] = v
+		}
+		// rate limit headers describe this request, not the cached one.
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+
+		resp.Status = "200 OK"
+		resp.StatusCode = http.StatusOK
+		resp.Header = header
+		resp.ContentLength = int64(len(cached.Body))
+		resp.Body = ioutil.NopCloser(bytes.NewReader(cached.Body))
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		t.Cache.Set(key, &CachedResponse{
+			ETag:   resp.Header.Get("ETag"),
+			Header: resp.Header,
+			Body:   body,
+		})
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return resp, nil
+}
+
+func (t *ETagTransport) transport() http.RoundTripper {
+	if t.Transport == nil {
+		return http.DefaultTransport
+	}
+
+	return t.Transport
+}
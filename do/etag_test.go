@@ -0,0 +1,105 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagServer(hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("RateLimit-Remaining", r.Header.Get("If-None-Match")+"left")
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"droplets":[]}`))
+	}))
+}
+
+func testETagTransport(t *testing.T, cache ETagCache) {
+	var hits int32
+	ts := newETagServer(&hits)
+	defer ts.Close()
+
+	client := &http.Client{Transport: NewETagTransport(nil, cache)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(ts.URL + "/v2/droplets")
+		require.NoError(t, err)
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"droplets":[]}`, string(body))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		if i == 1 {
+			assert.Equal(t, `"v1"left`, resp.Header.Get("RateLimit-Remaining"))
+		}
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestETagTransportMemory(t *testing.T) {
+	testETagTransport(t, NewMemoryETagCache())
+}
+
+func TestETagTransportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctl-etag")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testETagTransport(t, NewFileETagCache(dir))
+
+	// keys without a stored response miss.
+	_, ok := NewFileETagCache(dir).Get("missing")
+	assert.False(t, ok)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, os.FileMode(0600), files[0].Mode().Perm())
+}
+
+func TestETagTransportSkipsOtherMethods(t *testing.T) {
+	var hits int32
+	ts := newETagServer(&hits)
+	defer ts.Close()
+
+	cache := NewMemoryETagCache()
+	client := &http.Client{Transport: NewETagTransport(nil, cache)}
+
+	resp, err := client.Post(ts.URL+"/v2/droplets", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, ok := cache.Get(ts.URL + "/v2/droplets")
+	assert.False(t, ok)
+}
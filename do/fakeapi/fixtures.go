@@ -0,0 +1,106 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeapi
+
+import (
+	"github.com/digitalocean/godo"
+)
+
+// FixtureSet is the data a Server starts with.
+type FixtureSet struct {
+	Account godo.Account
+	// Collections maps a collection path, such as "droplets" or
+	// "domains/example.com/records", to the resources in it.
+	Collections map[string][]interface{}
+}
+
+// Fixtures returns canned resources for every service: an account, regions,
+// sizes, images, an SSH key, droplets, a domain with records, a floating IP,
+// a volume and a tag.
+func Fixtures() *FixtureSet {
+	nyc1 := &godo.Region{Slug: "nyc1", Name: "New York 1", Sizes: []string{"512mb", "1gb"}, Available: true}
+	sfo2 := &godo.Region{Slug: "sfo2", Name: "San Francisco 2", Sizes: []string{"512mb", "1gb"}, Available: true}
+
+	ubuntu := &godo.Image{
+		ID: 100, Name: "16.04.1 x64", Distribution: "Ubuntu", Slug: "ubuntu-16-04-x64",
+		Public: true, Regions: []string{"nyc1", "sfo2"}, MinDiskSize: 20, Type: "snapshot",
+	}
+	snapshot := &godo.Image{
+		ID: 101, Name: "web-1-base", Distribution: "Ubuntu",
+		Regions: []string{"nyc1"}, MinDiskSize: 20, Type: "snapshot",
+		Created: "2016-09-01T12:00:00Z",
+	}
+
+	web := &godo.Droplet{
+		ID: 300, Name: "web-1", Memory: 512, Vcpus: 1, Disk: 20,
+		Region: nyc1, Image: ubuntu, SizeSlug: "512mb", Status: "active",
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "192.0.2.10", Netmask: "255.255.255.0", Gateway: "192.0.2.254", Type: "public"},
+		}},
+		Created:     "2016-09-01T10:00:00Z",
+		SnapshotIDs: []int{101},
+		Tags:        []string{"web"},
+		VolumeIDs:   []string{},
+	}
+	db := &godo.Droplet{
+		ID: 301, Name: "db-1", Memory: 1024, Vcpus: 1, Disk: 30,
+		Region: nyc1, Image: ubuntu, SizeSlug: "1gb", Status: "active",
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "192.0.2.11", Netmask: "255.255.255.0", Gateway: "192.0.2.254", Type: "public"},
+		}},
+		Created:   "2016-09-01T10:05:00Z",
+		VolumeIDs: []string{"506f78a4-e098-11e5-ad9f-000f53306ae1"},
+	}
+
+	return &FixtureSet{
+		Account: godo.Account{
+			DropletLimit: 25, FloatingIPLimit: 3, Email: "sammy@example.com",
+			UUID: "b6fr89dbf6d9156cace5f3c78dc9851d957381ef", EmailVerified: true, Status: "active",
+		},
+		Collections: map[string][]interface{}{
+			"regions": {nyc1, sfo2},
+			"sizes": {
+				&godo.Size{Slug: "512mb", Memory: 512, Vcpus: 1, Disk: 20, Transfer: 1, PriceMonthly: 5, PriceHourly: 0.00744, Regions: []string{"nyc1", "sfo2"}, Available: true},
+				&godo.Size{Slug: "1gb", Memory: 1024, Vcpus: 1, Disk: 30, Transfer: 2, PriceMonthly: 10, PriceHourly: 0.01488, Regions: []string{"nyc1", "sfo2"}, Available: true},
+			},
+			"images": {ubuntu, snapshot},
+			"account/keys": {
+				&godo.Key{ID: 200, Name: "sammy", Fingerprint: "3b:16:bf:e4:8b:00:8b:b8:59:8c:a9:d3:f0:19:45:fa", PublicKey: "ssh-rsa AAAAB3NzaC1yc2E sammy@example.com"},
+			},
+			"droplets": {web, db},
+			"domains": {
+				&godo.Domain{Name: "example.com", TTL: 1800},
+			},
+			"domains/example.com/records": {
+				&godo.DomainRecord{ID: 400, Type: "A", Name: "@", Data: "192.0.2.10"},
+				&godo.DomainRecord{ID: 401, Type: "CNAME", Name: "www", Data: "@"},
+			},
+			"floating_ips": {
+				&godo.FloatingIP{IP: "198.51.100.5", Region: nyc1, Droplet: web},
+			},
+			"volumes": {
+				map[string]interface{}{
+					"id": "506f78a4-e098-11e5-ad9f-000f53306ae1", "name": "db-data",
+					"region": nyc1, "size_gigabytes": 10, "description": "database storage",
+					"droplet_ids": []int{301}, "created_at": "2016-09-01T10:10:00Z",
+				},
+			},
+			"tags": {
+				map[string]interface{}{"name": "web", "resources": map[string]interface{}{
+					"droplets": map[string]interface{}{"count": 1, "last_tagged": web},
+				}},
+			},
+		},
+	}
+}
@@ -0,0 +1,545 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeapi provides an in-memory fake of the DigitalOcean API, served
+// with httptest, so doctl commands and the do services can be exercised end
+// to end without credentials.
+//
+// The fake supports listing with pagination, getting, creating, updating
+// and deleting resources, and actions which stay in progress for a
+// configurable number of polls. Failures can be injected to exercise error
+// handling.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// kind describes a collection of resources.
+type kind struct {
+	// plural and singular are the keys lists and single resources are
+	// wrapped in.
+	plural, singular string
+	// idFields are the fields a resource can be addressed by.
+	idFields []string
+}
+
+var kinds = map[string]kind{
+	"droplets":     {plural: "droplets", singular: "droplet", idFields: []string{"id"}},
+	"domains":      {plural: "domains", singular: "domain", idFields: []string{"name"}},
+	"records":      {plural: "domain_records", singular: "domain_record", idFields: []string{"id"}},
+	"images":       {plural: "images", singular: "image", idFields: []string{"id", "slug"}},
+	"sizes":        {plural: "sizes", singular: "size", idFields: []string{"slug"}},
+	"regions":      {plural: "regions", singular: "region", idFields: []string{"slug"}},
+	"keys":         {plural: "ssh_keys", singular: "ssh_key", idFields: []string{"id", "fingerprint"}},
+	"floating_ips": {plural: "floating_ips", singular: "floating_ip", idFields: []string{"ip"}},
+	"volumes":      {plural: "volumes", singular: "volume", idFields: []string{"id"}},
+	"tags":         {plural: "tags", singular: "tag", idFields: []string{"name"}},
+	"actions":      {plural: "actions", singular: "action", idFields: []string{"id"}},
+	"snapshots":    {plural: "snapshots", singular: "snapshot", idFields: []string{"id"}},
+	"backups":      {plural: "backups", singular: "backup", idFields: []string{"id"}},
+	"kernels":      {plural: "kernels", singular: "kernel", idFields: []string{"id"}},
+	"neighbors":    {plural: "droplets", singular: "droplet", idFields: []string{"id"}},
+}
+
+type resource map[string]interface{}
+
+type failure struct {
+	status  int
+	message string
+}
+
+// Server is a fake DigitalOcean API.
+type Server struct {
+	*httptest.Server
+
+	// MaxPerPage caps the page size clients can ask for, so pagination
+	// can be exercised with few resources.
+	MaxPerPage int
+	// ActionPolls is how many times an action is reported in progress
+	// before it completes.
+	ActionPolls int
+	// BootPolls is how many times a created droplet is reported as new
+	// before it becomes active.
+	BootPolls int
+
+	mu          sync.Mutex
+	account     resource
+	collections map[string][]resource
+	polls       map[string]int
+	failures    []failure
+	requests    []string
+	nextID      int
+}
+
+// NewServer starts a fake API seeded with Fixtures. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		MaxPerPage:  200,
+		collections: map[string][]resource{},
+		polls:       map[string]int{},
+		nextID:      1000,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	f := Fixtures()
+	s.account = toResource(f.Account)
+	for path, items := range f.Collections {
+		for _, item := range items {
+			s.Add(path, item)
+		}
+	}
+
+	return s
+}
+
+// Client returns a godo client which talks to the fake.
+func (s *Server) Client() *godo.Client {
+	c := godo.NewClient(nil)
+	c.BaseURL, _ = url.Parse(s.URL)
+	return c
+}
+
+// Add stores v, which is marshalled to JSON, in the collection at path,
+// e.g. "droplets" or "domains/example.com/records".
+func (s *Server) Add(path string, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path = strings.Trim(path, "/")
+	s.collections[path] = append(s.collections[path], toResource(v))
+}
+
+// Reset removes every resource in the collection at path.
+func (s *Server) Reset(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.collections, strings.Trim(path, "/"))
+}
+
+// Len returns the number of resources in the collection at path.
+func (s *Server) Len(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.collections[strings.Trim(path, "/")])
+}
+
+// FailNext makes the next n requests fail with status.
+func (s *Server) FailNext(n, status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, message: message})
+	}
+}
+
+// Requests returns the requests received so far as "METHOD /path?query".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", "5000")
+	w.Header().Set("RateLimit-Remaining", "4999")
+	w.Header().Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		if f.status == http.StatusTooManyRequests {
+			w.Header().Set("RateLimit-Remaining", "0")
+		}
+		s.writeError(w, f.status, f.message)
+		return
+	}
+
+	var body resource
+	if r.Body != nil {
+		b, _ := ioutil.ReadAll(r.Body)
+		if len(b) > 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				s.writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v2"), "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "account":
+		s.write(w, http.StatusOK, resource{"account": s.account})
+	case segments[len(segments)-1] == "actions" && r.Method == "POST":
+		s.createAction(w, segments[:len(segments)-1], body)
+	case len(segments) >= 2 && segments[len(segments)-2] == "actions" && r.Method == "GET":
+		s.getAction(w, segments[len(segments)-1])
+	case len(segments) >= 2 && segments[0] == "tags" && segments[len(segments)-1] == "resources":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.serveCollection(w, r, segments, body)
+	}
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, segments []string, body resource) {
+	name := segments[len(segments)-1]
+	if _, ok := kinds[name]; ok {
+		path := strings.Join(segments, "/")
+		switch r.Method {
+		case "GET":
+			s.list(w, r, path)
+		case "POST":
+			s.create(w, path, body)
+		default:
+			s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	if len(segments) < 2 {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+
+	path := strings.Join(segments[:len(segments)-1], "/")
+	k, ok := kinds[segments[len(segments)-2]]
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+
+	i := s.find(path, k, name)
+	if i < 0 {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+	item := s.collections[path][i]
+
+	switch r.Method {
+	case "GET":
+		if path == "droplets" && item["status"] == "new" {
+			key := "droplets/" + name
+			s.polls[key]++
+			if s.polls[key] > s.BootPolls {
+				item["status"] = "active"
+			}
+		}
+		s.write(w, http.StatusOK, resource{k.singular: item})
+	case "PUT":
+		for key, v := range body {
+			item[key] = v
+		}
+		s.write(w, http.StatusOK, resource{k.singular: item})
+	case "DELETE":
+		items := s.collections[path]
+		s.collections[path] = append(items[:i:i], items[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) find(path string, k kind, id string) int {
+	for i, item := range s.collections[path] {
+		for _, f := range k.idFields {
+			if v, ok := item[f]; ok && formatValue(v) == id {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, path string) {
+	k := kinds[path[strings.LastIndex(path, "/")+1:]]
+	items := s.collections[path]
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = 20
+	}
+	if s.MaxPerPage > 0 && perPage > s.MaxPerPage {
+		perPage = s.MaxPerPage
+	}
+
+	lastPage := (len(items) + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	pageURL := func(p int) string {
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	pages := resource{}
+	if page > 1 {
+		pages["first"] = pageURL(1)
+		pages["prev"] = pageURL(page - 1)
+	}
+	if page < lastPage {
+		pages["next"] = pageURL(page + 1)
+		pages["last"] = pageURL(lastPage)
+	}
+
+	s.write(w, http.StatusOK, resource{
+		k.plural: append([]resource{}, items[start:end]...),
+		"links":  resource{"pages": pages},
+		"meta":   resource{"total": len(items)},
+	})
+}
+
+func (s *Server) create(w http.ResponseWriter, path string, body resource) {
+	name := path[strings.LastIndex(path, "/")+1:]
+	k := kinds[name]
+
+	if body == nil {
+		body = resource{}
+	}
+	item := resource{}
+	for key, v := range body {
+		item[key] = v
+	}
+
+	s.nextID++
+	switch name {
+	case "droplets":
+		item = resource{
+			"id":         s.nextID,
+			"name":       body["name"],
+			"status":     "new",
+			"created_at": time.Now().UTC().Format(time.RFC3339),
+			"region":     resource{"slug": body["region"]},
+			"size_slug":  body["size"],
+			"image":      s.lookupImage(body["image"]),
+			"tags":       body["tags"],
+			"networks": resource{"v4": []resource{{
+				"ip_address": fmt.Sprintf("192.0.2.%d", s.nextID%250+1),
+				"netmask":    "255.255.255.0",
+				"gateway":    "192.0.2.254",
+				"type":       "public",
+			}}},
+		}
+	case "floating_ips":
+		item = resource{
+			"ip":     fmt.Sprintf("198.51.100.%d", s.nextID%250+1),
+			"region": resource{"slug": body["region"]},
+		}
+		if id, ok := body["droplet_id"]; ok {
+			if i := s.find("droplets", kinds["droplets"], formatValue(id)); i >= 0 {
+				d := s.collections["droplets"][i]
+				item["droplet"] = d
+				item["region"] = d["region"]
+			}
+		}
+	case "volumes":
+		item["id"] = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.nextID)
+		item["region"] = resource{"slug": body["region"]}
+		item["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	case "domains", "tags":
+	default:
+		item["id"] = s.nextID
+	}
+
+	if i := s.find(path, k, formatValue(item[k.idFields[0]])); i >= 0 {
+		s.writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s is already in use", k.idFields[0]))
+		return
+	}
+	s.collections[path] = append(s.collections[path], item)
+
+	resp := resource{k.singular: item}
+	status := http.StatusCreated
+	if name == "droplets" {
+		a := s.newAction("create", "droplet", item["id"])
+		resp["links"] = resource{"actions": []resource{{
+			"id":   a["id"],
+			"rel":  "create",
+			"href": fmt.Sprintf("%s/v2/actions/%v", s.URL, a["id"]),
+		}}}
+		status = http.StatusAccepted
+	}
+
+	s.write(w, status, resp)
+}
+
+// lookupImage returns the stored image with the given id or slug, or a
+// minimal image if there is none.
+func (s *Server) lookupImage(image interface{}) resource {
+	if i := s.find("images", kinds["images"], formatValue(image)); i >= 0 {
+		return s.collections["images"][i]
+	}
+
+	return resource{"slug": image}
+}
+
+func (s *Server) newAction(actionType, resourceType string, resourceID interface{}) resource {
+	s.nextID++
+	a := resource{
+		"id":            s.nextID,
+		"status":        "in-progress",
+		"type":          actionType,
+		"started_at":    time.Now().UTC().Format(time.RFC3339),
+		"resource_id":   resourceID,
+		"resource_type": resourceType,
+	}
+	if s.ActionPolls == 0 {
+		a["status"] = "completed"
+		a["completed_at"] = a["started_at"]
+	}
+
+	s.collections["actions"] = append(s.collections["actions"], a)
+	return a
+}
+
+func (s *Server) createAction(w http.ResponseWriter, target []string, body resource) {
+	if len(target) < 2 {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+
+	path := strings.Join(target[:len(target)-1], "/")
+	id := target[len(target)-1]
+	k, ok := kinds[path[strings.LastIndex(path, "/")+1:]]
+	if !ok || s.find(path, k, id) < 0 {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+
+	actionType, _ := body["type"].(string)
+	if actionType == "" {
+		s.writeError(w, http.StatusUnprocessableEntity, "type is required")
+		return
+	}
+
+	var resourceID interface{} = id
+	if n, err := strconv.Atoi(id); err == nil {
+		resourceID = n
+	}
+
+	a := s.newAction(actionType, k.singular, resourceID)
+	s.write(w, http.StatusCreated, resource{"action": a})
+}
+
+func (s *Server) getAction(w http.ResponseWriter, id string) {
+	i := s.find("actions", kinds["actions"], id)
+	if i < 0 {
+		s.writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+		return
+	}
+
+	a := s.collections["actions"][i]
+	if a["status"] == "in-progress" {
+		s.polls["actions/"+id]++
+		if s.polls["actions/"+id] > s.ActionPolls {
+			a["status"] = "completed"
+			a["completed_at"] = time.Now().UTC().Format(time.RFC3339)
+		}
+	}
+
+	s.write(w, http.StatusOK, resource{"action": a})
+}
+
+func (s *Server) write(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	id := strings.ToLower(strings.Replace(http.StatusText(status), " ", "_", -1))
+	if status == http.StatusNotFound {
+		id = "not_found"
+	}
+
+	b, _ := json.Marshal(resource{"id": id, "message": message})
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// toResource converts v to the generic form resources are stored in.
+func toResource(v interface{}) resource {
+	if r, ok := v.(resource); ok {
+		return r
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("fakeapi: can't marshal %T: %v", v, err))
+	}
+
+	var r resource
+	if err := json.Unmarshal(b, &r); err != nil {
+		panic(fmt.Sprintf("fakeapi: %T is not an object: %v", v, err))
+	}
+
+	return r
+}
+
+// formatValue formats an id for comparison with a path segment. Numbers
+// decoded from JSON are float64, so they are formatted without decimals.
+func formatValue(v interface{}) string {
+	switch n := v.(type) {
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(n)
+	default:
+		return fmt.Sprint(v)
+	}
+}
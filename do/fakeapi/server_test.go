@@ -0,0 +1,132 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.MaxPerPage = 1
+	for i := 0; i < 3; i++ {
+		s.Add("droplets", &godo.Droplet{ID: 500 + i, Name: "extra", Status: "active"})
+	}
+
+	list, err := do.NewDropletsService(s.Client()).List()
+	require.NoError(t, err)
+	assert.Len(t, list, 5)
+
+	pages := 0
+	for _, r := range s.Requests() {
+		if strings.HasPrefix(r, "GET /v2/droplets?") {
+			pages++
+		}
+	}
+	assert.Equal(t, 5, pages)
+}
+
+func TestServerCreateAndWait(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ds := do.NewDropletsService(s.Client())
+	d, err := ds.Create(&godo.DropletCreateRequest{
+		Name:   "new-1",
+		Region: "sfo2",
+		Size:   "1gb",
+		Image:  godo.DropletCreateImage{Slug: "ubuntu-16-04-x64"},
+	}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "new-1", d.Name)
+	assert.Equal(t, "active", d.Status)
+	assert.Equal(t, "sfo2", d.Region.Slug)
+	assert.Equal(t, 100, d.Image.ID)
+	assert.Equal(t, 3, s.Len("droplets"))
+
+	ip, err := d.PublicIPv4()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ip)
+}
+
+func TestServerActions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.ActionPolls = 2
+	client := s.Client()
+
+	a, _, err := client.DropletActions.PowerOff(300)
+	require.NoError(t, err)
+	assert.Equal(t, "in-progress", a.Status)
+
+	statuses := []string{}
+	for i := 0; i < 3; i++ {
+		a, _, err = client.Actions.Get(a.ID)
+		require.NoError(t, err)
+		statuses = append(statuses, a.Status)
+	}
+	assert.Equal(t, []string{"in-progress", "in-progress", "completed"}, statuses)
+}
+
+func TestServerGetUpdateDelete(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client := s.Client()
+
+	key, _, err := client.Keys.GetByFingerprint("3b:16:bf:e4:8b:00:8b:b8:59:8c:a9:d3:f0:19:45:fa")
+	require.NoError(t, err)
+	assert.Equal(t, 200, key.ID)
+
+	key, _, err = client.Keys.UpdateByID(200, &godo.KeyUpdateRequest{Name: "renamed"})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", key.Name)
+
+	record, _, err := client.Domains.Record("example.com", 401)
+	require.NoError(t, err)
+	assert.Equal(t, "www", record.Name)
+
+	_, err = client.Domains.DeleteRecord("example.com", 401)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Len("domains/example.com/records"))
+
+	_, err = do.NewDropletsService(client).Get(999)
+	assert.True(t, do.IsNotFound(err))
+}
+
+func TestServerFailNext(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.FailNext(1, http.StatusTooManyRequests, "API Rate limit exceeded.")
+
+	as := do.NewAccountService(s.Client())
+	_, err := as.Get()
+	assert.True(t, do.IsRateLimited(err))
+
+	a, err := as.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "sammy@example.com", a.Email)
+}
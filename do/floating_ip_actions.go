@@ -41,7 +41,7 @@ func NewFloatingIPActionsService(godoClient *godo.Client) FloatingIPActionsServi
 func (fia *floatingIPActionsService) Assign(ip string, dropletID int) (*Action, error) {
 	a, _, err := fia.client.FloatingIPActions.Assign(ip, dropletID)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
@@ -50,7 +50,7 @@ func (fia *floatingIPActionsService) Assign(ip string, dropletID int) (*Action,
 func (fia *floatingIPActionsService) Unassign(ip string) (*Action, error) {
 	a, _, err := fia.client.FloatingIPActions.Unassign(ip)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
@@ -59,7 +59,7 @@ func (fia *floatingIPActionsService) Unassign(ip string) (*Action, error) {
 func (fia *floatingIPActionsService) Get(ip string, actionID int) (*Action, error) {
 	a, _, err := fia.client.FloatingIPActions.Get(ip, actionID)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
@@ -82,7 +82,7 @@ func (fia *floatingIPActionsService) List(ip string, opt *godo.ListOptions) ([]A
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Actions, len(si))
@@ -62,7 +62,7 @@ func (fis *floatingIPsService) List() (FloatingIPs, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	var list FloatingIPs
@@ -77,7 +77,7 @@ func (fis *floatingIPsService) List() (FloatingIPs, error) {
 func (fis *floatingIPsService) Get(ip string) (*FloatingIP, error) {
 	fip, _, err := fis.client.FloatingIPs.Get(ip)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &FloatingIP{FloatingIP: fip}, nil
@@ -86,7 +86,7 @@ func (fis *floatingIPsService) Get(ip string) (*FloatingIP, error) {
 func (fis *floatingIPsService) Create(ficr *godo.FloatingIPCreateRequest) (*FloatingIP, error) {
 	fip, _, err := fis.client.FloatingIPs.Create(ficr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &FloatingIP{FloatingIP: fip}, nil
@@ -94,5 +94,5 @@ func (fis *floatingIPsService) Create(ficr *godo.FloatingIPCreateRequest) (*Floa
 
 func (fis *floatingIPsService) Delete(ip string) error {
 	_, err := fis.client.FloatingIPs.Delete(ip)
-	return err
+	return wrapError(err)
 }
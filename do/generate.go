@@ -0,0 +1,19 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+// Mocks for every service interface in this package live in do/mocks. Run
+// go generate after changing a service; mocks_test.go in do/mocks fails
+// when a mock is missing or out of date.
+//go:generate mockery -name Service$ -note "Generated: please do not edit by hand"
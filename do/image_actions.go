@@ -38,7 +38,7 @@ func NewImageActionsService(client *godo.Client) ImageActionsService {
 func (ia *imageActionsService) Get(imageID, actionID int) (*Action, error) {
 	a, _, err := ia.client.ImageActions.Get(imageID, actionID)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
@@ -47,7 +47,7 @@ func (ia *imageActionsService) Get(imageID, actionID int) (*Action, error) {
 func (ia *imageActionsService) Transfer(imageID int, transferRequest *godo.ActionRequest) (*Action, error) {
 	a, _, err := ia.client.ImageActions.Transfer(imageID, transferRequest)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
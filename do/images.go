@@ -27,6 +27,7 @@ type Images []Image
 // ImagesService is the godo ImagesService interface.
 type ImagesService interface {
 	List(public bool) (Images, error)
+	Each(public bool, fn func(Image) error) error
 	ListDistribution(public bool) (Images, error)
 	ListApplication(public bool) (Images, error)
 	ListUser(public bool) (Images, error)
@@ -53,6 +54,14 @@ func (is *imagesService) List(public bool) (Images, error) {
 	return is.listImages(is.client.Images.List, public)
 }
 
+func (is *imagesService) Each(public bool, fn func(Image) error) error {
+	err := EachPage(imagesGenerator(is.client.Images.List, public), func(item interface{}) error {
+		image := item.(godo.Image)
+		return fn(Image{Image: &image})
+	})
+	return wrapError(err)
+}
+
 func (is *imagesService) ListDistribution(public bool) (Images, error) {
 	return is.listImages(is.client.Images.ListDistribution, public)
 }
@@ -68,7 +77,7 @@ func (is *imagesService) ListUser(public bool) (Images, error) {
 func (is *imagesService) GetByID(id int) (*Image, error) {
 	i, _, err := is.client.Images.GetByID(id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Image{Image: i}, nil
@@ -77,7 +86,7 @@ func (is *imagesService) GetByID(id int) (*Image, error) {
 func (is *imagesService) GetBySlug(slug string) (*Image, error) {
 	i, _, err := is.client.Images.GetBySlug(slug)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Image{Image: i}, nil
@@ -86,7 +95,7 @@ func (is *imagesService) GetBySlug(slug string) (*Image, error) {
 func (is *imagesService) Update(id int, iur *godo.ImageUpdateRequest) (*Image, error) {
 	i, _, err := is.client.Images.Update(id, iur)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Image{Image: i}, nil
@@ -94,13 +103,30 @@ func (is *imagesService) Update(id int, iur *godo.ImageUpdateRequest) (*Image, e
 
 func (is *imagesService) Delete(id int) error {
 	_, err := is.client.Images.Delete(id)
-	return err
+	return wrapError(err)
 }
 
 type listFn func(*godo.ListOptions) ([]godo.Image, *godo.Response, error)
 
 func (is *imagesService) listImages(lFn listFn, public bool) (Images, error) {
-	fn := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+	si, err := PaginateResp(imagesGenerator(lFn, public))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var list Images
+	for i := range si {
+		image := si[i].(godo.Image)
+		list = append(list, Image{Image: &image})
+	}
+
+	return list, nil
+}
+
+// imagesGenerator lists images with lFn, keeping only public ones when
+// public is set.
+func imagesGenerator(lFn listFn, public bool) Generator {
+	return func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
 		list, resp, err := lFn(opt)
 		if err != nil {
 			return nil, nil, err
@@ -115,17 +141,4 @@ func (is *imagesService) listImages(lFn listFn, public bool) (Images, error) {
 
 		return si, resp, err
 	}
-
-	si, err := PaginateResp(fn)
-	if err != nil {
-		return nil, err
-	}
-
-	var list Images
-	for i := range si {
-		image := si[i].(godo.Image)
-		list = append(list, Image{Image: &image})
-	}
-
-	return list, nil
 }
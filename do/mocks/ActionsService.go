@@ -10,6 +10,20 @@ type ActionsService struct {
 	mock.Mock
 }
 
+// Each provides a mock function with given fields: _a0
+func (_m *ActionsService) Each(_a0 func(do.Action) error) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(do.Action) error) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0
 func (_m *ActionsService) Get(_a0 int) (*do.Action, error) {
 	ret := _m.Called(_a0)
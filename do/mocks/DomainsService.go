@@ -58,6 +58,29 @@ func (_m *DomainsService) CreateRecord(_a0 string, _a1 *godo.DomainRecordEditReq
 	return r0, r1
 }
 
+// CreateRecordWithTTL provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DomainsService) CreateRecordWithTTL(_a0 string, _a1 *godo.DomainRecordEditRequest, _a2 int) (*do.DomainRecord, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *do.DomainRecord
+	if rf, ok := ret.Get(0).(func(string, *godo.DomainRecordEditRequest, int) *do.DomainRecord); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*do.DomainRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *godo.DomainRecordEditRequest, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: _a0
 func (_m *DomainsService) Delete(_a0 string) error {
 	ret := _m.Called(_a0)
@@ -109,6 +132,48 @@ func (_m *DomainsService) EditRecord(_a0 string, _a1 int, _a2 *godo.DomainRecord
 	return r0, r1
 }
 
+// EditRecordTTL provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DomainsService) EditRecordTTL(_a0 string, _a1 int, _a2 int) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, int) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Each provides a mock function with given fields: _a0
+func (_m *DomainsService) Each(_a0 func(do.Domain) error) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(do.Domain) error) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EachRecord provides a mock function with given fields: _a0, _a1
+func (_m *DomainsService) EachRecord(_a0 string, _a1 func(do.DomainRecord) error) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(do.DomainRecord) error) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0
 func (_m *DomainsService) Get(_a0 string) (*do.Domain, error) {
 	ret := _m.Called(_a0)
@@ -178,6 +243,27 @@ func (_m *DomainsService) Record(_a0 string, _a1 int) (*do.DomainRecord, error)
 	return r0, r1
 }
 
+// RecordTTL provides a mock function with given fields: _a0, _a1
+func (_m *DomainsService) RecordTTL(_a0 string, _a1 int) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, int) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Records provides a mock function with given fields: _a0
 func (_m *DomainsService) Records(_a0 string) (do.DomainRecords, error) {
 	ret := _m.Called(_a0)
@@ -132,6 +132,20 @@ func (_m *DropletsService) DeleteByTag(_a0 string) error {
 	return r0
 }
 
+// Each provides a mock function with given fields: _a0
+func (_m *DropletsService) Each(_a0 func(do.Droplet) error) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(do.Droplet) error) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0
 func (_m *DropletsService) Get(_a0 int) (*do.Droplet, error) {
 	ret := _m.Called(_a0)
@@ -26,6 +26,20 @@ func (_m *ImagesService) Delete(id int) error {
 	return r0
 }
 
+// Each provides a mock function with given fields: public, fn
+func (_m *ImagesService) Each(public bool, fn func(do.Image) error) error {
+	ret := _m.Called(public, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool, func(do.Image) error) error); ok {
+		r0 = rf(public, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *ImagesService) GetByID(id int) (*do.Image, error) {
 	ret := _m.Called(id)
@@ -49,6 +49,20 @@ func (_m *KeysService) Delete(id string) error {
 	return r0
 }
 
+// Each provides a mock function with given fields: fn
+func (_m *KeysService) Each(fn func(do.SSHKey) error) error {
+	ret := _m.Called(fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(do.SSHKey) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: id
 func (_m *KeysService) Get(id string) (*do.SSHKey, error) {
 	ret := _m.Called(id)
@@ -0,0 +1,115 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mocks
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/stretchr/testify/mock"
+)
+
+// serviceMocks pairs each do service interface with its mock.
+var serviceMocks = map[string]struct {
+	iface interface{}
+	mock  interface{}
+}{
+	"AccountService":           {(*do.AccountService)(nil), &AccountService{}},
+	"ActionsService":           {(*do.ActionsService)(nil), &ActionsService{}},
+	"DomainsService":           {(*do.DomainsService)(nil), &DomainsService{}},
+	"DropletActionsService":    {(*do.DropletActionsService)(nil), &DropletActionsService{}},
+	"DropletsService":          {(*do.DropletsService)(nil), &DropletsService{}},
+	"FloatingIPActionsService": {(*do.FloatingIPActionsService)(nil), &FloatingIPActionsService{}},
+	"FloatingIPsService":       {(*do.FloatingIPsService)(nil), &FloatingIPsService{}},
+	"ImageActionsService":      {(*do.ImageActionsService)(nil), &ImageActionsService{}},
+	"ImagesService":            {(*do.ImagesService)(nil), &ImagesService{}},
+	"KeysService":              {(*do.KeysService)(nil), &KeysService{}},
+	"RegionsService":           {(*do.RegionsService)(nil), &RegionsService{}},
+	"SizesService":             {(*do.SizesService)(nil), &SizesService{}},
+	"TagsService":              {(*do.TagsService)(nil), &TagsService{}},
+	"VolumeActionsService":     {(*do.VolumeActionsService)(nil), &VolumeActionsService{}},
+	"VolumesService":           {(*do.VolumesService)(nil), &VolumesService{}},
+}
+
+// serviceInterfaces returns the names of the service interfaces declared in
+// the do package.
+func serviceInterfaces(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "..", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parsing do package: %v", err)
+	}
+
+	var names []string
+	for _, f := range pkgs["do"].Files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, ok := ts.Type.(*ast.InterfaceType); ok && strings.HasSuffix(ts.Name.Name, "Service") {
+					names = append(names, ts.Name.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func TestEveryServiceHasMock(t *testing.T) {
+	for _, name := range serviceInterfaces(t) {
+		if _, ok := serviceMocks[name]; !ok {
+			t.Errorf("do.%s has no mock; run go generate ./do/ and add it to serviceMocks", name)
+		}
+	}
+}
+
+func TestMocksAreUpToDate(t *testing.T) {
+	embedded := reflect.TypeOf(&mock.Mock{})
+
+	for name, sm := range serviceMocks {
+		iface := reflect.TypeOf(sm.iface).Elem()
+		mt := reflect.TypeOf(sm.mock)
+
+		if !mt.Implements(iface) {
+			t.Errorf("mock %s does not implement do.%s; run go generate ./do/", name, name)
+			continue
+		}
+
+		for i := 0; i < mt.NumMethod(); i++ {
+			m := mt.Method(i).Name
+			if _, ok := iface.MethodByName(m); ok {
+				continue
+			}
+			if _, ok := embedded.MethodByName(m); ok {
+				continue
+			}
+			t.Errorf("mock %s has method %s which do.%s does not; run go generate ./do/", name, m, name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+)
+
+// RequestObserver is told about every API request. Start is called before
+// the request is sent and may return a replacement request, for instance
+// one carrying trace headers or a context holding a span. The returned
+// function is called once the request completes.
+//
+// RequestObserver has the same shape as a tracing span, so an OpenTelemetry
+// tracer can be adapted to it by starting a span in Start and ending it in
+// the returned function.
+type RequestObserver interface {
+	Start(req *http.Request) (*http.Request, func(resp *http.Response, err error))
+}
+
+// RequestObserverFunc adapts a function to a RequestObserver.
+type RequestObserverFunc func(req *http.Request) (*http.Request, func(resp *http.Response, err error))
+
+var _ RequestObserver = RequestObserverFunc(nil)
+
+// Start calls f.
+func (f RequestObserverFunc) Start(req *http.Request) (*http.Request, func(*http.Response, error)) {
+	return f(req)
+}
+
+// RequestContext returns an observer which sends every request with ctx, so
+// cancelling ctx abandons the requests made through the do services, for
+// instance to enforce a deadline or stop on an interrupt.
+func RequestContext(ctx context.Context) RequestObserver {
+	return RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+		return req.WithContext(ctx), nil
+	})
+}
+
+// ObservedTransport is an http.RoundTripper which reports each request to
+// its observers. Use it as the transport of the http.Client given to godo
+// to observe requests made through the do services.
+type ObservedTransport struct {
+	Transport http.RoundTripper
+	Observers []RequestObserver
+}
+
+var _ http.RoundTripper = &ObservedTransport{}
+
+// NewObservedTransport creates an ObservedTransport which sends requests
+// with transport.
+func NewObservedTransport(transport http.RoundTripper, observers ...RequestObserver) *ObservedTransport {
+	return &ObservedTransport{Transport: transport, Observers: observers}
+}
+
+// RoundTrip implements http.RoundTripper. Observers are finished in the
+// reverse of the order they were started.
+func (t *ObservedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	finishers := make([]func(*http.Response, error), 0, len(t.Observers))
+	for _, o := range t.Observers {
+		var finish func(*http.Response, error)
+		req, finish = o.Start(req)
+		if finish != nil {
+			finishers = append(finishers, finish)
+		}
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+
+	for i := len(finishers) - 1; i >= 0; i-- {
+		finishers[i](resp, err)
+	}
+
+	return resp, err
+}
+
+var operationIDRe = regexp.MustCompile(`/(\d+|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|\d+\.\d+\.\d+\.\d+)(/|$)`)
+
+// OperationName returns a low cardinality name for req, such as
+// "GET /v2/droplets/{id}", suitable for naming spans or metrics.
+func OperationName(req *http.Request) string {
+	path := req.URL.Path
+	for {
+		p := operationIDRe.ReplaceAllString(path, "/{id}$2")
+		if p == path {
+			break
+		}
+		path = p
+	}
+
+	return req.Method + " " + path
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservedTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace", r.Header.Get("X-Trace"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	var events []string
+	observer := func(name string) RequestObserver {
+		return RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+			events = append(events, "start "+name)
+
+			r := new(http.Request)
+			*r = *req
+			r.Header = http.Header{"X-Trace": {req.Header.Get("X-Trace") + name}}
+
+			return r, func(resp *http.Response, err error) {
+				assert.NoError(t, err)
+				events = append(events, "finish "+name+" "+resp.Header.Get("X-Trace"))
+			}
+		})
+	}
+
+	client := &http.Client{Transport: NewObservedTransport(nil, observer("a"), observer("b"))}
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, []string{"start a", "start b", "finish b ab", "finish a ab"}, events)
+}
+
+func TestRequestContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &http.Client{Transport: NewObservedTransport(nil, RequestContext(ctx))}
+	_, err := client.Get(ts.URL)
+	assert.Error(t, err)
+}
+
+func TestOperationName(t *testing.T) {
+	cases := map[string]string{
+		"/v2/droplets":                                     "GET /v2/droplets",
+		"/v2/droplets/123":                                 "GET /v2/droplets/{id}",
+		"/v2/droplets/123/actions/456":                     "GET /v2/droplets/{id}/actions/{id}",
+		"/v2/floating_ips/10.0.0.1":                        "GET /v2/floating_ips/{id}",
+		"/v2/volumes/506f78a4-e098-11e5-ad9f-000f53306ae1": "GET /v2/volumes/{id}",
+		"/v2/domains/example.com":                          "GET /v2/domains/example.com",
+	}
+
+	for path, expected := range cases {
+		req := &http.Request{Method: "GET", URL: &url.URL{Path: path}}
+		assert.Equal(t, expected, OperationName(req))
+	}
+}
@@ -14,6 +14,7 @@ limitations under the License.
 package do
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -91,6 +92,38 @@ func PaginateResp(gen Generator) ([]interface{}, error) {
 	return l.list, nil
 }
 
+// ErrStopIteration can be returned by an Each callback to stop iterating
+// without reporting an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// EachPage calls fn for every item produced by gen. Pages are fetched one at
+// a time, so the full list is never held in memory. Iteration stops at the
+// first error returned by gen or fn.
+func EachPage(gen Generator, fn func(interface{}) error) error {
+	opt := &godo.ListOptions{Page: 1, PerPage: perPage}
+
+	for {
+		items, resp, err := gen(opt)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil
+		}
+		opt.Page++
+	}
+}
+
 func fetchPage(gen Generator, page int) ([]interface{}, error) {
 	opt := &godo.ListOptions{Page: page, PerPage: 200}
 	items, _, err := gen(opt)
@@ -14,6 +14,7 @@ limitations under the License.
 package do
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -39,6 +40,50 @@ func Test_PaginateResp(t *testing.T) {
 	assert.Len(t, list, 5)
 }
 
+func pagedGen(pages int) Generator {
+	return func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		resp := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{}}}
+		if opt.Page < pages {
+			resp.Links.Pages.Last = "http://example.com/?page=3"
+		}
+		return []interface{}{opt.Page*10 + 1, opt.Page*10 + 2}, resp, nil
+	}
+}
+
+func Test_EachPage(t *testing.T) {
+	var got []interface{}
+	err := EachPage(pagedGen(3), func(item interface{}) error {
+		got = append(got, item)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{11, 12, 21, 22, 31, 32}, got)
+}
+
+func Test_EachPage_stop(t *testing.T) {
+	var got []interface{}
+	err := EachPage(pagedGen(3), func(item interface{}) error {
+		got = append(got, item)
+		if len(got) == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{11, 12, 21}, got)
+}
+
+func Test_EachPage_error(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	err := EachPage(pagedGen(3), func(item interface{}) error {
+		calls++
+		return boom
+	})
+	assert.Equal(t, boom, err)
+	assert.Equal(t, 1, calls)
+}
+
 func Test_Pagination_fetchPage(t *testing.T) {
 	gen := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
 		items := []interface{}{}
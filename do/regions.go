@@ -59,7 +59,7 @@ func (rs *regionsService) List() (Regions, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Regions, len(si))
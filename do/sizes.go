@@ -59,7 +59,7 @@ func (rs *sizesService) List() (Sizes, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Sizes, len(si))
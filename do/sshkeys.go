@@ -32,6 +32,7 @@ type SSHKeys []SSHKey
 // KeysService is the godo KeysService interface.
 type KeysService interface {
 	List() (SSHKeys, error)
+	Each(fn func(SSHKey) error) error
 	Get(id string) (*SSHKey, error)
 	Create(kcr *godo.KeyCreateRequest) (*SSHKey, error)
 	Update(id string, kur *godo.KeyUpdateRequest) (*SSHKey, error)
@@ -68,7 +69,7 @@ func (ks *keysService) List() (SSHKeys, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(SSHKeys, len(si))
@@ -80,6 +81,28 @@ func (ks *keysService) List() (SSHKeys, error) {
 	return list, nil
 }
 
+func (ks *keysService) Each(fn func(SSHKey) error) error {
+	f := func(opt *godo.ListOptions) ([]interface{}, *godo.Response, error) {
+		list, resp, err := ks.client.Keys.List(opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		si := make([]interface{}, len(list))
+		for i := range list {
+			si[i] = list[i]
+		}
+
+		return si, resp, err
+	}
+
+	err := EachPage(f, func(item interface{}) error {
+		k := item.(godo.Key)
+		return fn(SSHKey{Key: &k})
+	})
+	return wrapError(err)
+}
+
 func (ks *keysService) Get(id string) (*SSHKey, error) {
 	var err error
 	var k *godo.Key
@@ -95,7 +118,7 @@ func (ks *keysService) Get(id string) (*SSHKey, error) {
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &SSHKey{Key: k}, nil
@@ -104,7 +127,7 @@ func (ks *keysService) Get(id string) (*SSHKey, error) {
 func (ks *keysService) Create(kcr *godo.KeyCreateRequest) (*SSHKey, error) {
 	k, _, err := ks.client.Keys.Create(kcr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &SSHKey{Key: k}, nil
@@ -120,7 +143,7 @@ func (ks *keysService) Update(id string, kur *godo.KeyUpdateRequest) (*SSHKey, e
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &SSHKey{Key: k}, nil
@@ -135,5 +158,5 @@ func (ks *keysService) Delete(id string) error {
 		_, err = ks.client.Keys.DeleteByFingerprint(id)
 	}
 
-	return err
+	return wrapError(err)
 }
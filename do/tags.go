@@ -64,7 +64,7 @@ func (ts *tagsService) List() (Tags, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	list := make(Tags, len(si))
@@ -79,7 +79,7 @@ func (ts *tagsService) List() (Tags, error) {
 func (ts *tagsService) Get(name string) (*Tag, error) {
 	t, _, err := ts.client.Tags.Get(name)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Tag{Tag: t}, nil
@@ -88,7 +88,7 @@ func (ts *tagsService) Get(name string) (*Tag, error) {
 func (ts *tagsService) Create(tcr *godo.TagCreateRequest) (*Tag, error) {
 	t, _, err := ts.client.Tags.Create(tcr)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Tag{Tag: t}, nil
@@ -96,20 +96,20 @@ func (ts *tagsService) Create(tcr *godo.TagCreateRequest) (*Tag, error) {
 
 func (ts *tagsService) Update(name string, tur *godo.TagUpdateRequest) error {
 	_, err := ts.client.Tags.Update(name, tur)
-	return err
+	return wrapError(err)
 }
 
 func (ts *tagsService) Delete(name string) error {
 	_, err := ts.client.Tags.Delete(name)
-	return err
+	return wrapError(err)
 }
 
 func (ts *tagsService) TagResources(name string, trr *godo.TagResourcesRequest) error {
 	_, err := ts.client.Tags.TagResources(name, trr)
-	return err
+	return wrapError(err)
 }
 
 func (ts *tagsService) UntagResources(name string, urr *godo.UntagResourcesRequest) error {
 	_, err := ts.client.Tags.UntagResources(name, urr)
-	return err
+	return wrapError(err)
 }
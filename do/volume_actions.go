@@ -24,7 +24,7 @@ func NewVolumeActionsService(godoClient *godo.Client) VolumeActionsService {
 
 func (das *volumeActionsService) handleActionResponse(a *godo.Action, err error) (*Action, error) {
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return &Action{Action: a}, nil
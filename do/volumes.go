@@ -48,7 +48,7 @@ func (a *volumesService) List() ([]Volume, error) {
 
 	si, err := PaginateResp(f)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 
 	}
 
@@ -66,7 +66,7 @@ func (a *volumesService) List() ([]Volume, error) {
 func (a *volumesService) CreateVolume(r *godo.VolumeCreateRequest) (*Volume, error) {
 	al, _, err := a.client.Storage.CreateVolume(r)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 
 	}
 	return &Volume{Volume: al}, nil
@@ -77,7 +77,7 @@ func (a *volumesService) DeleteVolume(id string) error {
 
 	_, err := a.client.Storage.DeleteVolume(id)
 	if err != nil {
-		return err
+		return wrapError(err)
 
 	}
 
@@ -88,7 +88,7 @@ func (a *volumesService) DeleteVolume(id string) error {
 func (a *volumesService) Get(id string) (*Volume, error) {
 	d, _, err := a.client.Storage.GetVolume(id)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 
 	}
 
@@ -15,20 +15,25 @@ package doctl
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/blang/semver"
+	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/doctl/pkg/runner"
 	"github.com/digitalocean/doctl/pkg/ssh"
 	"github.com/digitalocean/godo"
 	jww "github.com/spf13/jwalterweatherman"
 	"github.com/spf13/viper"
+	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
 
@@ -165,23 +170,68 @@ type Config interface {
 // LiveConfig is an implementation of Config for live values.
 type LiveConfig struct {
 	godoClient *godo.Client
+	httpClient *http.Client
+	observers  []do.RequestObserver
+	settings   *viper.Viper
 }
 
 var _ Config = &LiveConfig{}
 
+// NewLiveConfigWithClient creates a LiveConfig which uses godoClient for all
+// API requests instead of building one from the configured access token.
+func NewLiveConfigWithClient(godoClient *godo.Client) *LiveConfig {
+	return &LiveConfig{godoClient: godoClient}
+}
+
+// NewLiveConfigWithHTTPClient creates a LiveConfig which authenticates with
+// the configured access token, but sends requests through httpClient. This
+// allows callers to supply their own transport for instrumentation or
+// recording.
+func NewLiveConfigWithHTTPClient(httpClient *http.Client) *LiveConfig {
+	return &LiveConfig{httpClient: httpClient}
+}
+
+// AddRequestObserver registers an observer for every API request made by
+// the client. It has no effect once the client has been created.
+func (c *LiveConfig) AddRequestObserver(o do.RequestObserver) {
+	c.observers = append(c.observers, o)
+}
+
+// WithSettings creates a LiveConfig which builds its client from the
+// access-token and http-cache-dir in settings, such as those of another
+// config context, instead of the global configuration. The HTTP client and
+// request observers are shared with c, so its requests are observed, traced
+// and cached in the same way.
+func (c *LiveConfig) WithSettings(settings *viper.Viper) *LiveConfig {
+	return &LiveConfig{
+		httpClient: c.httpClient,
+		observers:  c.observers,
+		settings:   settings,
+	}
+}
+
 // GetGodoClient returns a GodoClient.
 func (c *LiveConfig) GetGodoClient(trace bool) (*godo.Client, error) {
 	if c.godoClient != nil {
 		return c.godoClient, nil
 	}
 
-	token := viper.GetString("access-token")
+	setting := viper.GetString
+	if c.settings != nil {
+		setting = c.settings.GetString
+	}
+
+	token := setting("access-token")
 	if token == "" {
 		return nil, fmt.Errorf("access token is required")
 	}
 
 	tokenSource := &TokenSource{AccessToken: token}
-	oauthClient := oauth2.NewClient(oauth2.NoContext, tokenSource)
+	ctx := oauth2.NoContext
+	if c.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	}
+	oauthClient := oauth2.NewClient(ctx, tokenSource)
 
 	if trace {
 		r := newRecorder(oauthClient.Transport)
@@ -200,19 +250,49 @@ func (c *LiveConfig) GetGodoClient(trace bool) (*godo.Client, error) {
 		oauthClient.Transport = r
 	}
 
+	var cache do.ETagCache = do.NewMemoryETagCache()
+	if dir := setting("http-cache-dir"); dir != "" {
+		// responses are only valid for the account they were fetched with.
+		sum := sha256.Sum256([]byte(token))
+		cache = do.NewFileETagCache(filepath.Join(dir, hex.EncodeToString(sum[:8])))
+	}
+	oauthClient.Transport = do.NewETagTransport(oauthClient.Transport, cache)
+
+	if len(c.observers) > 0 {
+		oauthClient.Transport = do.NewObservedTransport(oauthClient.Transport, c.observers...)
+	}
+
 	c.godoClient = godo.NewClient(oauthClient)
 	return c.godoClient, nil
 }
 
 // SSH creates a ssh connection to a host.
 func (c *LiveConfig) SSH(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
-	return &ssh.Runner{
+	// options which are missing or of the wrong type are left at their
+	// zero values.
+	agentForwarding, _ := opts[ArgsSSHAgentForwarding].(bool)
+	jumpHost, _ := opts[ArgsSSHJump].(string)
+	localForwards, _ := opts[ArgsSSHLocalForward].([]string)
+	remoteForwards, _ := opts[ArgsSSHRemoteForward].([]string)
+
+	r := &ssh.Runner{
 		User:            user,
 		Host:            host,
 		KeyPath:         keyPath,
 		Port:            port,
-		AgentForwarding: opts[ArgsSSHAgentForwarding].(bool),
+		AgentForwarding: agentForwarding,
+		JumpHost:        jumpHost,
+		LocalForwards:   localForwards,
+		RemoteForwards:  remoteForwards,
+	}
+
+	if mode, ok := opts[ArgsSSHStrictHostChecking].(string); ok && mode != "" {
+		r.StrictHostKeyChecking = mode
+		r.KnownHostsFile, _ = opts[ArgsSSHKnownHostsFile].(string)
+		r.HostKeyAlias, _ = opts[ArgsSSHHostKeyAlias].(string)
 	}
+
+	return r
 }
 
 // Set sets a config key.
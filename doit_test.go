@@ -14,8 +14,17 @@ limitations under the License.
 package doctl
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
 )
 
 func TestMain(m *testing.M) {
@@ -93,3 +102,141 @@ type stubLatestRelease struct {
 func (slr stubLatestRelease) LatestVersion() (string, error) {
 	return slr.version, nil
 }
+
+type recordingTransport struct {
+	reqs []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.reqs = append(rt.reqs, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"account":{"uuid":"abc"}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestLiveConfigWithHTTPClient(t *testing.T) {
+	viper.Set("access-token", "secret")
+	defer viper.Set("access-token", "")
+
+	rt := &recordingTransport{}
+	c := NewLiveConfigWithHTTPClient(&http.Client{Transport: rt})
+
+	client, err := c.GetGodoClient(false)
+	if err != nil {
+		t.Fatalf("GetGodoClient() unexpected error: %v", err)
+	}
+
+	a, _, err := client.Account.Get()
+	if err != nil {
+		t.Fatalf("Account.Get() unexpected error: %v", err)
+	}
+	if got, want := a.UUID, "abc"; got != want {
+		t.Errorf("account uuid = %q; want = %q", got, want)
+	}
+
+	if got, want := len(rt.reqs), 1; got != want {
+		t.Fatalf("requests sent through transport = %d; want = %d", got, want)
+	}
+	if got, want := rt.reqs[0].Header.Get("Authorization"), "Bearer secret"; got != want {
+		t.Errorf("authorization header = %q; want = %q", got, want)
+	}
+}
+
+func TestLiveConfigWithSettings(t *testing.T) {
+	viper.Set("access-token", "secret")
+	defer viper.Set("access-token", "")
+
+	var observed int
+	rt := &recordingTransport{}
+	c := NewLiveConfigWithHTTPClient(&http.Client{Transport: rt})
+	c.AddRequestObserver(do.RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+		observed++
+		return req, nil
+	}))
+
+	settings := viper.New()
+	settings.Set("access-token", "other")
+
+	client, err := c.WithSettings(settings).GetGodoClient(false)
+	if err != nil {
+		t.Fatalf("GetGodoClient() unexpected error: %v", err)
+	}
+
+	if _, _, err := client.Account.Get(); err != nil {
+		t.Fatalf("Account.Get() unexpected error: %v", err)
+	}
+
+	if got, want := len(rt.reqs), 1; got != want {
+		t.Fatalf("requests sent through transport = %d; want = %d", got, want)
+	}
+	if got, want := rt.reqs[0].Header.Get("Authorization"), "Bearer other"; got != want {
+		t.Errorf("authorization header = %q; want = %q", got, want)
+	}
+	if got, want := observed, 1; got != want {
+		t.Errorf("observed requests = %d; want = %d", got, want)
+	}
+}
+
+func TestLiveConfigWithClient(t *testing.T) {
+	gc := godo.NewClient(nil)
+	c := NewLiveConfigWithClient(gc)
+
+	client, err := c.GetGodoClient(false)
+	if err != nil {
+		t.Fatalf("GetGodoClient() unexpected error: %v", err)
+	}
+	if client != gc {
+		t.Errorf("GetGodoClient() did not return the supplied client")
+	}
+}
+
+func TestLiveConfigRequestObserver(t *testing.T) {
+	viper.Set("access-token", "secret")
+	defer viper.Set("access-token", "")
+
+	var names []string
+	var statuses []int
+	c := NewLiveConfigWithHTTPClient(&http.Client{Transport: &recordingTransport{}})
+	c.AddRequestObserver(do.RequestObserverFunc(func(req *http.Request) (*http.Request, func(*http.Response, error)) {
+		names = append(names, do.OperationName(req))
+		return req, func(resp *http.Response, err error) {
+			statuses = append(statuses, resp.StatusCode)
+		}
+	}))
+
+	client, err := c.GetGodoClient(false)
+	if err != nil {
+		t.Fatalf("GetGodoClient() unexpected error: %v", err)
+	}
+
+	if _, _, err := client.Account.Get(); err != nil {
+		t.Fatalf("Account.Get() unexpected error: %v", err)
+	}
+
+	if got, want := strings.Join(names, ","), "GET /v2/account"; got != want {
+		t.Errorf("observed operations = %q; want = %q", got, want)
+	}
+	if got, want := len(statuses), 1; got != want || statuses[0] != http.StatusOK {
+		t.Errorf("observed statuses = %v; want = [200]", statuses)
+	}
+}
+
+func TestLiveConfigSSHMissingOptions(t *testing.T) {
+	c := &LiveConfig{}
+
+	r, ok := c.SSH("root", "10.0.0.1", "", 22, ssh.Options{}).(*ssh.Runner)
+	if !ok {
+		t.Fatalf("SSH() did not return an *ssh.Runner")
+	}
+	if r.AgentForwarding || r.JumpHost != "" || r.LocalForwards != nil || r.RemoteForwards != nil {
+		t.Errorf("SSH() = %+v; want zero-valued options", r)
+	}
+
+	r = c.SSH("root", "10.0.0.1", "", 22, ssh.Options{ArgsSSHStrictHostChecking: "yes"}).(*ssh.Runner)
+	if r.StrictHostKeyChecking != "yes" || r.KnownHostsFile != "" || r.HostKeyAlias != "" {
+		t.Errorf("SSH() = %+v; want strict checking without known hosts file or alias", r)
+	}
+}
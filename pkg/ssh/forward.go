@@ -0,0 +1,163 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Forward is a port forward between a listening address and a target.
+type Forward struct {
+	Listen string
+	Target string
+}
+
+// ParseForward parses a [bind_address:]port:host:hostport forward. IPv6
+// addresses are written in brackets, as in [::1]:8080:[::1]:80.
+func ParseForward(s string) (Forward, error) {
+	parts, err := splitForward(s)
+	if err != nil {
+		return Forward{}, err
+	}
+	if len(parts) == 3 {
+		parts = append([]string{"localhost"}, parts...)
+	}
+
+	if len(parts) != 4 {
+		return Forward{}, fmt.Errorf("invalid forward %q, expected [bind_address:]port:host:hostport", s)
+	}
+
+	for _, p := range []string{parts[1], parts[3]} {
+		if _, err := strconv.Atoi(p); err != nil {
+			return Forward{}, fmt.Errorf("invalid port %q in forward %q", p, s)
+		}
+	}
+
+	return Forward{
+		Listen: net.JoinHostPort(parts[0], parts[1]),
+		Target: net.JoinHostPort(parts[2], parts[3]),
+	}, nil
+}
+
+// splitForward splits a forward on the colons outside brackets, removing
+// the brackets around hosts.
+func splitForward(s string) ([]string, error) {
+	var parts []string
+	for s != "" {
+		var part string
+		if strings.HasPrefix(s, "[") {
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid forward %q, missing ]", s)
+			}
+			part, s = s[1:end], s[end+1:]
+			if s != "" && !strings.HasPrefix(s, ":") {
+				return nil, fmt.Errorf("invalid forward %q, expected : after ]", s)
+			}
+		} else if i := strings.Index(s, ":"); i >= 0 {
+			part, s = s[:i], s[i:]
+		} else {
+			part, s = s, ""
+		}
+
+		parts = append(parts, part)
+		s = strings.TrimPrefix(s, ":")
+	}
+
+	return parts, nil
+}
+
+// startForwards listens for the runner's forwards over conn. The returned
+// func closes the listeners, and must be called when the session ends. If
+// a forward can't be started, the listeners already started are closed.
+func startForwards(conn *ssh.Client, r *Runner) (func(), error) {
+	var listeners []net.Listener
+	stop := func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}
+
+	for _, s := range r.LocalForwards {
+		f, err := ParseForward(s)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+
+		l, err := net.Listen("tcp", f.Listen)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		listeners = append(listeners, l)
+		go serveForward(l, func() (net.Conn, error) { return conn.Dial("tcp", f.Target) })
+	}
+
+	for _, s := range r.RemoteForwards {
+		f, err := ParseForward(s)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+
+		l, err := conn.Listen("tcp", f.Listen)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		listeners = append(listeners, l)
+		go serveForward(l, func() (net.Conn, error) { return net.Dial("tcp", f.Target) })
+	}
+
+	return stop, nil
+}
+
+func serveForward(l net.Listener, dial func() (net.Conn, error)) {
+	defer l.Close()
+
+	for {
+		in, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer in.Close()
+
+			out, err := dial()
+			if err != nil {
+				return
+			}
+			defer out.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				_, _ = io.Copy(out, in)
+				done <- struct{}{}
+			}()
+			go func() {
+				_, _ = io.Copy(in, out)
+				done <- struct{}{}
+			}()
+			<-done
+		}()
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForward(t *testing.T) {
+	cases := []struct {
+		in     string
+		listen string
+		target string
+	}{
+		{"5432:10.0.0.5:5432", "localhost:5432", "10.0.0.5:5432"},
+		{"0.0.0.0:8080:localhost:3000", "0.0.0.0:8080", "localhost:3000"},
+		{"[::1]:8080:db:80", "[::1]:8080", "db:80"},
+		{"8080:[fd00::5]:80", "localhost:8080", "[fd00::5]:80"},
+	}
+
+	for _, c := range cases {
+		f, err := ParseForward(c.in)
+		require.NoError(t, err, c.in)
+		assert.Equal(t, Forward{Listen: c.listen, Target: c.target}, f, c.in)
+	}
+
+	for _, in := range []string{"5432", "a:b:c", "[::1:8080:h:80", "[::1]x:8080:h:80", "::1:8080:h:80"} {
+		_, err := ParseForward(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestStartForwardsClosesListenersOnError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	_, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	r := &Runner{LocalForwards: []string{"127.0.0.1:" + port + ":db:5432", "bad"}}
+	_, err = startForwards(nil, r)
+	assert.Error(t, err)
+
+	l, err = net.Listen("tcp", addr)
+	require.NoError(t, err, "the first forward's listener was left open")
+	l.Close()
+}
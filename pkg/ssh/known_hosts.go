@@ -0,0 +1,146 @@
+/*
+Copyright 2016 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const rebuildMarker = "# doctl-rebuild"
+
+// DropletHostAlias is the known_hosts name a droplet's host key is pinned
+// under, so the pin follows the droplet rather than its address.
+func DropletHostAlias(id int) string {
+	return fmt.Sprintf("droplet-%d", id)
+}
+
+// SyncDropletHost removes the pinned host key of a droplet from the
+// known_hosts file at path when the droplet has been rebuilt since the key
+// was pinned. rebuildID is the ID of the droplet's latest rebuild action, or
+// 0 if it was never rebuilt.
+func SyncDropletHost(path string, id, rebuildID int) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	alias := DropletHostAlias(id)
+	marker := fmt.Sprintf("%s %s ", rebuildMarker, alias)
+	current := marker + strconv.Itoa(rebuildID)
+
+	var lines []string
+	seen := false
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.HasPrefix(line, marker) {
+			seen = true
+			if line == current {
+				return nil
+			}
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if seen {
+		// the droplet was rebuilt, so its old host key is stale.
+		lines = removeHostLines(lines, alias)
+	}
+	lines = append(lines, current)
+
+	return ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+func removeHostLines(lines []string, alias string) []string {
+	var out []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && hostsContain(fields[0], alias) {
+			continue
+		}
+		out = append(out, line)
+	}
+
+	return out
+}
+
+func hostsContain(hosts, alias string) bool {
+	for _, h := range strings.Split(hosts, ",") {
+		if h == alias {
+			return true
+		}
+	}
+
+	return false
+}
+
+// knownHostsCallback checks host keys against the entries for alias in the
+// known_hosts file at path. mode follows ssh's StrictHostKeyChecking: "yes"
+// rejects unknown hosts, "accept-new" pins them on first use and "no" pins
+// them and accepts changed keys.
+func knownHostsCallback(path, alias, mode string) func(string, net.Addr, ssh.PublicKey) error {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		b, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		known := false
+		for _, line := range strings.Split(string(b), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || !hostsContain(fields[0], alias) || fields[1] != key.Type() {
+				continue
+			}
+
+			pinned, err := base64.StdEncoding.DecodeString(fields[2])
+			if err != nil {
+				continue
+			}
+
+			if bytes.Equal(pinned, key.Marshal()) {
+				return nil
+			}
+			known = true
+		}
+
+		switch {
+		case known && mode != "no":
+			return fmt.Errorf("host key for %s has changed", alias)
+		case !known && mode == "yes":
+			return fmt.Errorf("no host key is known for %s", alias)
+		case known:
+			return nil
+		}
+
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = fmt.Fprintf(f, "%s %s %s\n", alias, key.Type(), base64.StdEncoding.EncodeToString(key.Marshal()))
+		return err
+	}
+}
@@ -15,8 +15,10 @@ package ssh
 
 import (
 	"io"
+	"net"
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/digitalocean/doctl/pkg/runner"
 	"github.com/digitalocean/doctl/pkg/term"
@@ -26,12 +28,16 @@ import (
 // Options is the type used to specify options passed to the SSH command
 type Options map[string]interface{}
 
-func sshConnect(user string, host string, method ssh.AuthMethod) error {
+func sshConnect(r *Runner, host string, method ssh.AuthMethod) error {
 	sshc := &ssh.ClientConfig{
-		User: user,
+		User: r.User,
 		Auth: []ssh.AuthMethod{method},
 	}
-	conn, err := ssh.Dial("tcp", host, sshc)
+	if r.StrictHostKeyChecking != "" {
+		sshc.HostKeyCallback = knownHostsCallback(r.KnownHostsFile, r.HostKeyAlias, r.StrictHostKeyChecking)
+	}
+
+	conn, err := sshDial(host, r.JumpHost, sshc)
 	if err != nil {
 		return err
 	}
@@ -39,6 +45,12 @@ func sshConnect(user string, host string, method ssh.AuthMethod) error {
 		_ = conn.Close()
 	}()
 
+	stopForwards, err := startForwards(conn, r)
+	if err != nil {
+		return err
+	}
+	defer stopForwards()
+
 	session, err := conn.NewSession()
 	if err != nil {
 		return err
@@ -97,6 +109,44 @@ func sshConnect(user string, host string, method ssh.AuthMethod) error {
 	return err
 }
 
+// sshDial connects to host, through jump if it is set. The jump host is
+// authenticated with the same method as host, its host key is not pinned.
+func sshDial(host, jump string, sshc *ssh.ClientConfig) (*ssh.Client, error) {
+	if jump == "" {
+		return ssh.Dial("tcp", host, sshc)
+	}
+
+	jumpc := *sshc
+	jumpc.HostKeyCallback = nil
+	jumpAddr := jump
+	if i := strings.LastIndex(jumpAddr, "@"); i >= 0 {
+		jumpc.User = jumpAddr[:i]
+		jumpAddr = jumpAddr[i+1:]
+	}
+	if _, _, err := net.SplitHostPort(jumpAddr); err != nil {
+		jumpAddr = net.JoinHostPort(jumpAddr, "22")
+	}
+
+	bastion, err := ssh.Dial("tcp", jumpAddr, &jumpc)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := bastion.Dial("tcp", host)
+	if err != nil {
+		_ = bastion.Close()
+		return nil, err
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, host, sshc)
+	if err != nil {
+		_ = bastion.Close()
+		return nil, err
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
 // Runner runs ssh commands.
 type Runner struct {
 	User            string
@@ -104,6 +154,17 @@ type Runner struct {
 	KeyPath         string
 	Port            int
 	AgentForwarding bool
+	// JumpHost is an optional [user@]host[:port] to connect through.
+	JumpHost string
+	// LocalForwards and RemoteForwards are port forwards in ssh's
+	// [bind_address:]port:host:hostport form.
+	LocalForwards  []string
+	RemoteForwards []string
+	// StrictHostKeyChecking is yes, no or accept-new. When set, host keys
+	// are checked against KnownHostsFile under HostKeyAlias.
+	StrictHostKeyChecking string
+	KnownHostsFile        string
+	HostKeyAlias          string
 }
 
 var _ runner.Runner = &Runner{}
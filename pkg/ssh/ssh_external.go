@@ -38,6 +38,25 @@ func runExternalSSH(r *Runner) error {
 		args = append(args, "-A")
 	}
 
+	for _, f := range r.LocalForwards {
+		args = append(args, "-L", f)
+	}
+
+	for _, f := range r.RemoteForwards {
+		args = append(args, "-R", f)
+	}
+
+	if r.JumpHost != "" {
+		args = append(args, "-J", r.JumpHost)
+	}
+
+	if r.StrictHostKeyChecking != "" {
+		args = append(args,
+			"-o", "StrictHostKeyChecking="+r.StrictHostKeyChecking,
+			"-o", "UserKnownHostsFile="+r.KnownHostsFile,
+			"-o", "HostKeyAlias="+r.HostKeyAlias)
+	}
+
 	args = append(args, sshHost)
 
 	cmd := exec.Command("ssh", args...)
@@ -35,7 +35,7 @@ func runInternalSSH(r *Runner) error {
 		return err
 	}
 
-	if err := sshConnect(r.User, sshHost, ssh.PublicKeys(privateKey)); err != nil {
+	if err := sshConnect(r, sshHost, ssh.PublicKeys(privateKey)); err != nil {
 		// Password Auth if Key Auth Fails
 		fd := os.Stdin.Fd()
 		state, err := terminal.MakeRaw(int(fd))
@@ -50,7 +50,7 @@ func runInternalSSH(r *Runner) error {
 		if err != nil {
 			return err
 		}
-		if err := sshConnect(r.User, sshHost, ssh.Password(string(password))); err != nil {
+		if err := sshConnect(r, sshHost, ssh.Password(string(password))); err != nil {
 			return err
 		}
 	}
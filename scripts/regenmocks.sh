@@ -6,5 +6,4 @@ set -e
 
 go get github.com/vektra/mockery/.../
 
-cd do
-mockery -all -note "Generated: please do not edit by hand"
\ No newline at end of file
+go generate ./do/